@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+// All completion scripts call `tinyenv --complete WORDS...`,
+// where WORDS are the words before the cursor without the leading "tinyenv"
+
+var zshCompletions = `compctl -K _tinyenv tinyenv
+
+_tinyenv() {
+  local words completions
+  read -cA words
+  completions="$(tinyenv --complete "${(@)words[2,-2]}")"
+  reply=("${(ps:\n:)completions}")
+}
+`
+
+var bashCompletions = `_tinyenv() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  local IFS=$'\n'
+  COMPREPLY=($(compgen -W "$(tinyenv --complete "${COMP_WORDS[@]:1:COMP_CWORD-1}")" -- "$cur"))
+}
+complete -F _tinyenv tinyenv
+`
+
+var fishCompletions = `function __tinyenv_complete
+    set -l words (commandline -opc)
+    set -e words[1]
+    tinyenv --complete $words
+end
+complete -c tinyenv -f -a '(__tinyenv_complete)'
+`
+
+func complete(globalCommands []string, languageCommands []string, words []string) []string {
+	root, err := selectRoot()
+	if err != nil {
+		return nil
+	}
+	cfg, _ := readConfig(root)
+	languages := language.Names(root, cfg)
+
+	if len(words) == 0 {
+		return append(languages, globalCommands...)
+	}
+	if words[0] == "init" {
+		return []string{"zsh", "bash", "fish", "--hook"}
+	}
+	if words[0] == "install" {
+		out := []string{"-g", "--global"}
+		for _, l := range languages {
+			out = append(out, l+"@")
+		}
+		return out
+	}
+	if words[0] == "self-update" {
+		return []string{"--check"}
+	}
+	if words[0] == "outdated" {
+		return []string{"--major", "--json"}
+	}
+	if words[0] == "which" || words[0] == "whence" {
+		if len(words) > 1 {
+			return nil
+		}
+		entries, _ := os.ReadDir(filepath.Join(root, "bin"))
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name())
+		}
+		return out
+	}
+	if !slices.Contains(languages, words[0]) {
+		return nil
+	}
+	if len(words) == 1 {
+		return languageCommands
+	}
+	lang := &language.Language{Name: words[0], Root: filepath.Join(root, words[0]), Config: cfg}
+	command, args := words[1], words[2:]
+	installed := func() []string {
+		versions, _ := lang.Versions()
+		return versions
+	}
+	remote := func() []string {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		versions, _ := lang.CachedList(ctx, 24*time.Hour)
+		return append([]string{"latest"}, versions...)
+	}
+	switch command {
+	case "global", "uninstall":
+		if len(args) == 0 {
+			return installed()
+		}
+	case "reset":
+		if len(args) == 0 {
+			return append([]string{"-"}, installed()...)
+		}
+	case "versions":
+		if len(args) == 0 {
+			return []string{"--bare"}
+		}
+	case "upgrade":
+		return append([]string{"--major", "--remove"}, installed()...)
+	case "install":
+		if len(args) == 0 {
+			return append([]string{"-l", "-L", "-g", "--global"}, remote()...)
+		}
+		if len(args) == 1 && (args[0] == "-g" || args[0] == "--global") {
+			return remote()
+		}
+	}
+	return nil
+}
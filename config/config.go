@@ -2,9 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 )
 
 func NewFromFile(path string) (*Config, error) {
@@ -16,11 +18,37 @@ func NewFromFile(path string) (*Config, error) {
 	if err := json.Unmarshal(b, &cfg); err != nil {
 		return nil, fmt.Errorf("%s: %w", path, err)
 	}
+	if cfg == nil {
+		return nil, fmt.Errorf("%s: empty config", path)
+	}
+	for name, lang := range cfg.Languages {
+		if lang == nil || lang.Versions == nil || lang.Asset == "" {
+			return nil, fmt.Errorf("%s: languages.%s: need versions and asset", path, name)
+		}
+	}
 	return cfg, nil
 }
 
 type Config struct {
-	Rehash map[string]*Rehash `json:"rehash"`
+	Rehash    map[string]*Rehash   `json:"rehash"`
+	Hooks     map[string]*Hooks    `json:"hooks"`
+	Languages map[string]*Language `json:"languages"`
+	// languages in order of precedence, when several languages provide the same executable
+	Priority []string `json:"priority"`
+}
+
+// Prefer reports whether lang1 takes precedence over lang2 in Priority.
+// A language in Priority takes precedence over one that is not.
+func (c *Config) Prefer(lang1 string, lang2 string) bool {
+	if c == nil {
+		return false
+	}
+	i1 := slices.Index(c.Priority, lang1)
+	i2 := slices.Index(c.Priority, lang2)
+	if i1 == -1 {
+		return false
+	}
+	return i2 == -1 || i1 < i2
 }
 
 type Rehash struct {
@@ -85,3 +113,83 @@ func (r *Rehash) Target(name string) bool {
 	}
 	return true
 }
+
+type Hooks struct {
+	PostInstall   []*Hook `json:"post-install"`
+	PostGlobal    []*Hook `json:"post-global"`
+	PostUninstall []*Hook `json:"post-uninstall"`
+}
+
+type Hook struct {
+	Command string `json:"command"`
+	Fatal   bool   `json:"fatal"`
+}
+
+func (h *Hooks) Get(event string) []*Hook {
+	if h == nil {
+		return nil
+	}
+	switch event {
+	case "post-install":
+		return h.PostInstall
+	case "post-global":
+		return h.PostGlobal
+	case "post-uninstall":
+		return h.PostUninstall
+	}
+	return nil
+}
+
+// Language defines a language that tinyenv does not ship, for example
+//
+//	"terraform": {
+//	  "versions": {
+//	    "url": "https://api.releases.hashicorp.com/v1/releases/terraform?limit=20",
+//	    "json": "*.version"
+//	  },
+//	  "asset": "https://releases.hashicorp.com/terraform/{version}/terraform_{version}_{os}_{arch}.zip",
+//	  "strip": 0,
+//	  "bin_dirs": ["."]
+//	}
+type Language struct {
+	Versions *Versions         `json:"versions"`
+	Asset    string            `json:"asset"`
+	OSArch   map[string]string `json:"os_arch"`
+	Strip    *int              `json:"strip"`
+	BinDirs  []string          `json:"bin_dirs"`
+}
+
+// Versions tells where to find versions of a Language.
+// JSON is a dot separated path such as "releases.*.version", where "*" means every element of an array or object,
+// and "#" means the keys of an object. Otherwise, the first submatch (or the match) of Regexp is used.
+type Versions struct {
+	URL    string         `json:"url"`
+	JSON   string         `json:"json"`
+	Regexp *regexp.Regexp `json:"-"`
+}
+
+func (v *Versions) UnmarshalJSON(b []byte) error {
+	var data struct {
+		URL    string `json:"url"`
+		JSON   string `json:"json"`
+		Regexp string `json:"regexp"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	if data.URL == "" {
+		return errors.New("versions: missing url")
+	}
+	if (data.JSON == "") == (data.Regexp == "") {
+		return errors.New("versions: need either json or regexp")
+	}
+	*v = Versions{URL: data.URL, JSON: data.JSON}
+	if data.Regexp != "" {
+		reg, err := regexp.Compile(data.Regexp)
+		if err != nil {
+			return err
+		}
+		v.Regexp = reg
+	}
+	return nil
+}
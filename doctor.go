@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+type doctorReport struct {
+	broken bool
+}
+
+func (r *doctorReport) ok(format string, args ...any) {
+	fmt.Printf("[ok] "+format+"\n", args...)
+}
+
+func (r *doctorReport) ng(fix string, format string, args ...any) {
+	r.broken = true
+	fmt.Printf("[NG] "+format+"\n", args...)
+	fmt.Println("     fix: " + fix)
+}
+
+// doctor checks root and PATH, and returns false if something is broken
+func doctor(root string) bool {
+	r := &doctorReport{}
+	binDir := filepath.Join(root, "bin")
+
+	cfg, err := readConfig(root)
+	if err != nil {
+		r.ng("correct or remove "+filepath.Join(root, "config.json"), "invalid config.json: %v", err)
+	} else {
+		r.ok("config.json")
+	}
+
+	pathDirs := filepath.SplitList(os.Getenv("PATH"))
+	binIndex := slices.IndexFunc(pathDirs, func(dir string) bool {
+		return sameDir(dir, binDir)
+	})
+	if binIndex == -1 {
+		r.ng(`add `+"`"+`eval "$(tinyenv init zsh)"`+"`"+` (or bash, fish) to your shell rc file`, "%s is not in PATH", binDir)
+	} else {
+		r.ok("%s is in PATH", binDir)
+	}
+
+	if tarExec, err := exec.LookPath("gtar"); err == nil {
+		r.ok("tar: %s", tarExec)
+	} else if tarExec, err := exec.LookPath("tar"); err == nil {
+		out, _ := exec.Command(tarExec, "--version").Output()
+		if strings.Contains(string(out), "bsdtar") {
+			r.ok("tar: %s (bsdtar)", tarExec)
+		} else if _, err := exec.LookPath("xz"); err == nil {
+			r.ok("tar: %s with xz", tarExec)
+		} else {
+			r.ng("install xz", "%s cannot extract .tar.xz archives without xz", tarExec)
+		}
+	} else {
+		r.ng("install tar", "neither gtar nor tar is in PATH")
+	}
+
+	entries, _ := os.ReadDir(binDir)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(binDir, e.Name())
+		shim, err := language.ReadShim(path)
+		if err != nil {
+			continue
+		}
+		if !language.ExistsFS(shim.Target) {
+			r.ng("run `tinyenv "+shim.Language+" rehash`", "shim %s points at missing %s", path, shim.Target)
+		}
+		if binIndex == -1 {
+			continue
+		}
+		for _, dir := range pathDirs[:binIndex] {
+			other := filepath.Join(dir, e.Name())
+			if info, err := os.Stat(other); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+				r.ng("put "+binDir+" before "+dir+" in PATH", "%s shadows shim %s", other, path)
+				break
+			}
+		}
+	}
+
+	for _, err := range language.InvalidPlugins(root) {
+		r.ng("rename or remove the plugin", "plugin %v", err)
+	}
+
+	for _, name := range language.Names(root, cfg) {
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		version, err := lang.Version()
+		if err != nil {
+			continue
+		}
+		if dir := filepath.Join(lang.Root, "versions", version); !language.ExistsFS(dir) {
+			r.ng(fmt.Sprintf("run `tinyenv %s install -g %s` or `tinyenv %s global VERSION`", name, version, name),
+				"%s version %s is not installed", name, version)
+		} else {
+			r.ok("%s %s", name, version)
+		}
+	}
+
+	if r.broken {
+		fmt.Println("Some problems were found.")
+	}
+	return !r.broken
+}
+
+func sameDir(dir1 string, dir2 string) bool {
+	info1, err1 := os.Stat(dir1)
+	info2, err2 := os.Stat(dir2)
+	if err1 != nil || err2 != nil {
+		return filepath.Clean(dir1) == filepath.Clean(dir2)
+	}
+	return os.SameFile(info1, info2)
+}
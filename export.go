@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/skaji/tinyenv/config"
+	"github.com/skaji/tinyenv/language"
+)
+
+// machine is what `tinyenv export` writes and `tinyenv import` reads
+type machine struct {
+	Languages map[string]*machineLanguage `json:"languages"`
+	// the content of config.json
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+type machineLanguage struct {
+	Versions []string `json:"versions"`
+	Global   string   `json:"global,omitempty"`
+}
+
+func exportMachine(root string, cfg *config.Config, languages []string) (*machine, error) {
+	m := &machine{Languages: map[string]*machineLanguage{}}
+	for _, name := range languages {
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		versions, err := lang.Versions()
+		if err != nil || len(versions) == 0 {
+			continue
+		}
+		global, _ := lang.Version()
+		m.Languages[name] = &machineLanguage{Versions: versions, Global: global}
+	}
+	if b, err := os.ReadFile(filepath.Join(root, "config.json")); err == nil {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, fmt.Errorf("config.json: %w", err)
+		}
+		m.Config = buf.Bytes()
+	}
+	return m, nil
+}
+
+func readMachine(path string) (*machine, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m *machine
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if m == nil || len(m.Languages) == 0 {
+		return nil, errors.New(path + ": no languages to import")
+	}
+	return m, nil
+}
+
+// importMachine restores config.json, installs the versions in m that are missing,
+// and restores the global versions
+func importMachine(ctx context.Context, root string, m *machine) error {
+	if len(m.Config) > 0 {
+		if err := restoreConfig(root, m.Config); err != nil {
+			return err
+		}
+	}
+	cfg, err := readConfig(root)
+	if err != nil {
+		return err
+	}
+	languages := language.Names(root, cfg)
+
+	var items []*installItem
+	var langs []*language.Language
+	for _, name := range slices.Sorted(maps.Keys(m.Languages)) {
+		if !slices.Contains(languages, name) {
+			fmt.Fprintln(os.Stderr, "---> skip unknown language: "+name)
+			continue
+		}
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		if err := lang.Init(); err != nil {
+			return err
+		}
+		langs = append(langs, lang)
+		for _, version := range m.Languages[name].Versions {
+			if !language.ExistsFS(filepath.Join(lang.Root, "versions", version)) {
+				items = append(items, &installItem{lang: lang, version: version})
+			}
+		}
+	}
+
+	failed := false
+	if len(items) > 0 && installMany(ctx, items, false) != nil {
+		failed = true
+	}
+	for _, lang := range langs {
+		global := m.Languages[lang.Name].Global
+		if global == "" {
+			continue
+		}
+		if !language.ExistsFS(filepath.Join(lang.Root, "versions", global)) {
+			fmt.Fprintf(os.Stderr, "---> %s %s is not installed, so it is not set as global\n", lang.Name, global)
+			failed = true
+			continue
+		}
+		if err := lang.SetVersion(global); err != nil {
+			return err
+		}
+	}
+	// RehashAll removes the shims of languages it is not given, so give it every language
+	var all []*language.Language
+	for _, name := range languages {
+		all = append(all, &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg})
+	}
+	if err := language.RehashAll(root, cfg, all); err != nil {
+		return err
+	}
+	if failed {
+		return errors.New("failed to import some versions")
+	}
+	return nil
+}
+
+// restoreConfig writes config.json, keeping a different existing one as config.json.bak
+func restoreConfig(root string, content json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, content, "", "  "); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	buf.WriteByte('\n')
+	path := filepath.Join(root, "config.json")
+	if old, err := os.ReadFile(path); err == nil {
+		var oldBuf bytes.Buffer
+		if json.Compact(&oldBuf, old) == nil && bytes.Equal(oldBuf.Bytes(), content) {
+			return nil
+		}
+		fmt.Println("---> Saving " + path + " as " + path + ".bak")
+		if err := os.WriteFile(path+".bak", old, 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Println("---> Writing " + path)
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
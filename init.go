@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+// shellInit returns the shell code that `tinyenv init SHELL` prints.
+// With hook, it warns about uninstalled versions pinned in .tool-versions whenever the directory changes.
+func shellInit(root string, shell string, hook bool) (string, error) {
+	binDir := filepath.Join(root, "bin")
+	var b strings.Builder
+	switch shell {
+	case "zsh":
+		fmt.Fprintf(&b, "export PATH=%s:\"$PATH\"\n", shellQuote(binDir))
+		b.WriteString(zshCompletions)
+		if hook {
+			b.WriteString(`autoload -U add-zsh-hook
+_tinyenv_hook() { tinyenv --check-pinned }
+add-zsh-hook chpwd _tinyenv_hook
+_tinyenv_hook
+`)
+		}
+	case "bash":
+		fmt.Fprintf(&b, "export PATH=%s:\"$PATH\"\n", shellQuote(binDir))
+		b.WriteString(bashCompletions)
+		if hook {
+			b.WriteString(`_tinyenv_hook() {
+  if [[ "$PWD" != "$_TINYENV_LAST_PWD" ]]; then
+    _TINYENV_LAST_PWD="$PWD"
+    tinyenv --check-pinned
+  fi
+}
+PROMPT_COMMAND="_tinyenv_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+`)
+		}
+	case "fish":
+		fmt.Fprintf(&b, "set -gx PATH %s $PATH\n", shellQuote(binDir))
+		b.WriteString(fishCompletions)
+		if hook {
+			b.WriteString(`function __tinyenv_hook --on-variable PWD
+    tinyenv --check-pinned
+end
+__tinyenv_hook
+`)
+		}
+	default:
+		return "", errors.New("unsupported shell: " + shell + " (zsh, bash or fish)")
+	}
+	return b.String(), nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// checkPinned warns about versions pinned in tinyenv.json or .tool-versions that are not installed
+func checkPinned(root string, languages []string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	m, err := findManifest(cwd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tinyenv:", err)
+		return
+	}
+	if m == nil {
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Versions)) {
+		if !slices.Contains(languages, name) {
+			continue
+		}
+		version := m.resolved(name)
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name)}
+		installed, _ := lang.Versions()
+		if slices.Contains(installed, version) || slices.Contains(installed, "v"+version) {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "tinyenv: %s %s is pinned in %s, but not installed; run `tinyenv sync`\n",
+			name, version, m.path)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/skaji/tinyenv/config"
+	"github.com/skaji/tinyenv/language"
+	"golang.org/x/sync/errgroup"
+)
+
+// installItem is a version to install with installMany
+type installItem struct {
+	lang *language.Language
+	// the requested version, such as "latest", "22" or "v22.12.0"
+	version string
+	// records the download, and verifies it if ExpectedSHA256 is set; may be nil
+	recorder *language.DownloadRecorder
+
+	// the resolved version
+	installed string
+	// true if installed was already installed
+	skipped bool
+	err     error
+}
+
+func (item *installItem) String() string {
+	return item.lang.Name + "@" + item.version
+}
+
+// parseInstallItems parses LANG@VERSION arguments of the global install command.
+func parseInstallItems(root string, cfg *config.Config, languages []string, args []string) ([]*installItem, error) {
+	var items []*installItem
+	for _, arg := range args {
+		name, version, ok := strings.Cut(arg, "@")
+		if !ok || name == "" || version == "" {
+			return nil, errors.New("invalid argument " + arg + ", expect LANGUAGE@VERSION")
+		}
+		if !slices.Contains(languages, name) {
+			return nil, errors.New("unknown language: " + name)
+		}
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		items = append(items, &installItem{lang: lang, version: version})
+	}
+	return items, nil
+}
+
+// installMany resolves the versions of items, downloads and installs them in parallel, and prints a summary.
+// Items that resolve to the same version are installed once.
+// If global is true, the installed versions become the global versions.
+func installMany(ctx context.Context, items []*installItem, global bool) error {
+	var group errgroup.Group
+	group.SetLimit(4)
+	for _, item := range items {
+		group.Go(func() error {
+			if item.err = item.lang.Init(); item.err == nil {
+				item.installed, item.err = resolveVersion(ctx, item.lang, item.version)
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	var unique []*installItem
+	duplicates := map[*installItem]*installItem{}
+	first := map[string]*installItem{}
+	for _, item := range items {
+		if item.err != nil {
+			unique = append(unique, item)
+			continue
+		}
+		key := item.lang.Name + "@" + item.installed
+		if f, ok := first[key]; ok {
+			duplicates[item] = f
+			continue
+		}
+		first[key] = item
+		unique = append(unique, item)
+	}
+	if global {
+		seen := map[string]bool{}
+		for _, item := range unique {
+			if seen[item.lang.Name] {
+				return errors.New("cannot set several versions of " + item.lang.Name + " as global")
+			}
+			seen[item.lang.Name] = true
+		}
+	}
+
+	progress := language.NewProgress(os.Stdout)
+	for _, item := range unique {
+		if item.err != nil {
+			continue
+		}
+		group.Go(func() error {
+			item.skipped, item.err = installItemVersion(ctx, progress, item)
+			return nil
+		})
+	}
+	_ = group.Wait()
+	for item, f := range duplicates {
+		item.installed, item.skipped, item.err = f.installed, f.skipped, f.err
+	}
+
+	for _, item := range unique {
+		if item.err != nil || item.skipped {
+			continue
+		}
+		item.err = item.lang.RunHooks("post-install", item.installed)
+	}
+	if global {
+		for _, item := range unique {
+			if item.err != nil {
+				continue
+			}
+			if item.err = item.lang.SetVersion(item.installed); item.err != nil {
+				continue
+			}
+			if item.err = item.lang.Rehash(); item.err != nil {
+				continue
+			}
+			item.err = item.lang.RunHooks("post-global", item.installed)
+		}
+	}
+
+	fmt.Println("---> Summary")
+	failed := 0
+	for _, item := range unique {
+		switch {
+		case item.err != nil:
+			failed++
+			fmt.Printf("[ng] %s: %v\n", item, item.err)
+		case item.skipped:
+			fmt.Printf("[ok] %s %s (already installed)\n", item.lang.Name, item.installed)
+		default:
+			fmt.Printf("[ok] %s %s\n", item.lang.Name, item.installed)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to install %d of %d", failed, len(unique))
+	}
+	return nil
+}
+
+// installItemVersion installs the resolved version of item, and returns true if it is already installed.
+func installItemVersion(ctx context.Context, progress *language.Progress, item *installItem) (bool, error) {
+	if language.ExistsFS(filepath.Join(item.lang.Root, "versions", item.installed)) {
+		return true, nil
+	}
+	ctx = language.WithProgress(ctx, progress, item.String())
+	if item.recorder != nil {
+		ctx = language.WithDownloadRecorder(ctx, item.recorder)
+	}
+	installed, err := item.lang.Install(ctx, item.installed)
+	if err == nil {
+		item.installed = installed
+	}
+	return false, err
+}
+
+// resolveVersion resolves requested, such as "latest", "22", "22.12.0" or "v22.12.0", to a version of lang.
+// Installed versions are looked up first, so that resolving them does not need the network.
+func resolveVersion(ctx context.Context, lang *language.Language, requested string) (string, error) {
+	if requested == "latest" {
+		return lang.Latest(ctx)
+	}
+	for _, version := range []string{requested, "v" + requested} {
+		if language.ExistsFS(filepath.Join(lang.Root, "versions", version)) {
+			return version, nil
+		}
+	}
+	candidates, err := lang.List(ctx, true)
+	if err != nil {
+		// Install reports a better error if requested is not a version
+		return requested, nil
+	}
+	return language.ResolveVersion(requested, candidates), nil
+}
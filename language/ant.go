@@ -0,0 +1,25 @@
+package language
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var antDist = &ApacheDist{
+	Paths: []string{"ant/binaries/"},
+	// <a href="apache-ant-1.10.15-bin.tar.gz">apache-ant-1.10.15-bin.tar.gz</a>
+	Version: regexp.MustCompile(`<a href="apache-ant-([\d.]+)-bin\.tar\.gz">`),
+	Asset: func(version string) string {
+		return fmt.Sprintf("ant/binaries/apache-ant-%s-bin.tar.gz", version)
+	},
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "ant",
+		Description: "Apache Ant from archive.apache.org",
+		New: func(root string, opts *Options) Specific {
+			return &Apache{base: newBase(opts), Root: root, Dist: antDist}
+		},
+	})
+}
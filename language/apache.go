@@ -0,0 +1,137 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Apache installs binary releases of Apache projects.
+// Versions are scraped from the archive.apache.org directory listings;
+// archives are downloaded from downloads.apache.org if they are still there, otherwise from archive.apache.org.
+type Apache struct {
+	*base
+	Root string
+	Dist *ApacheDist
+}
+
+type ApacheDist struct {
+	// directories under dist/ to list, such as "solr/solr/"
+	Paths []string
+	// matches a version in the directory listings
+	Version *regexp.Regexp
+	// returns the path of the archive under dist/
+	Asset func(version string) string
+}
+
+const apacheArchiveURL = "https://archive.apache.org/dist/"
+
+const apacheDownloadsURL = "https://downloads.apache.org/"
+
+func (a *Apache) List(ctx context.Context, all bool) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, path := range a.Dist.Paths {
+		b, err := a.get(ctx, a.url(apacheArchiveURL+path))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range a.Dist.Version.FindAllStringSubmatch(string(b), -1) {
+			if !seen[m[1]] {
+				out = append(out, m[1])
+				seen[m[1]] = true
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no versions in " + apacheArchiveURL + strings.Join(a.Dist.Paths, ", "))
+	}
+	slices.SortStableFunc(out, func(v1, v2 string) int {
+		return semver.Compare("v"+v2, "v"+v1)
+	})
+	if !all && len(out) > 10 {
+		return out[:10], nil
+	}
+	return out, nil
+}
+
+func (a *Apache) Latest(ctx context.Context) (string, error) {
+	out, err := a.List(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("not found")
+	}
+	return out[0], nil
+}
+
+func (a *Apache) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := a.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	targetDir := filepath.Join(a.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	url := a.url(apacheDownloadsURL + a.Dist.Asset(version))
+	if err := a.head(ctx, url); err != nil {
+		url = a.url(apacheArchiveURL + a.Dist.Asset(version))
+	}
+	sha512, err := a.sha512(ctx, url+".sha512")
+	if err != nil {
+		return "", err
+	}
+	cacheFile := filepath.Join(a.Root, "cache", version+".tar.gz")
+	if err := os.MkdirAll(filepath.Join(a.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := a.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	if err := VerifySHA512(cacheFile, sha512); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := a.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// .sha512 files come in several formats:
+//
+//	<hash>
+//	<hash>  solr-9.7.0.tgz
+//	<hash> *apache-maven-3.9.9-bin.tar.gz
+//	apache-maven-3.6.3-bin.tar.gz: C7E2 A2BD ...
+func (a *Apache) sha512(ctx context.Context, url string) (string, error) {
+	b, err := a.get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	str := string(b)
+	if _, after, ok := strings.Cut(str, ": "); ok {
+		str = after
+	}
+	str = strings.Join(strings.Fields(str), "")
+	hash := regexp.MustCompile(`^[0-9a-fA-F]{128}`).FindString(str)
+	if hash == "" {
+		return "", errors.New("no sha512 in " + url)
+	}
+	return hash, nil
+}
@@ -0,0 +1,34 @@
+package language
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestApacheSHA512(t *testing.T) {
+	hash := strings.Repeat("0123456789abcdef", 8)
+	files := map[string]string{
+		"/plain":     hash + "\n",
+		"/sha512sum": hash + "  solr-9.7.0.tgz\n",
+		"/binary":    hash + " *apache-maven-3.9.9-bin.tar.gz\n",
+		"/gpg":       "apache-maven-3.6.3-bin.tar.gz: " + strings.ToUpper(hash[:64]) + "\n                               " + strings.ToUpper(hash[64:]) + "\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(files[req.URL.Path]))
+	}))
+	defer server.Close()
+
+	a := &Apache{}
+	for path := range files {
+		got, err := a.sha512(context.Background(), server.URL+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(got, hash) {
+			t.Errorf("%s: got %s", path, got)
+		}
+	}
+}
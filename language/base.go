@@ -1,11 +1,53 @@
 package language
 
-type base struct{}
+import (
+	"context"
+	"net/http"
+)
+
+type base struct {
+	options *Options
+}
+
+func newBase(opts *Options) *base {
+	return &base{options: opts}
+}
+
+func (b *base) opts() *Options {
+	if b == nil {
+		return nil
+	}
+	return b.options
+}
+
+func (b *base) url(u string) string {
+	return b.opts().URL(u)
+}
+
+func (b *base) get(ctx context.Context, url string) ([]byte, error) {
+	return b.opts().Get(ctx, url)
+}
+
+func (b *base) head(ctx context.Context, url string) error {
+	return b.opts().Head(ctx, url)
+}
+
+func (b *base) mirror(ctx context.Context, url string, targetFile string, modifier func(req *http.Request)) error {
+	return b.opts().Mirror(ctx, url, targetFile, modifier)
+}
+
+func (b *base) github() *GitHub {
+	return &GitHub{Options: b.opts()}
+}
 
 func (*base) BinDirs() []string {
 	return []string{"bin"}
 }
 
+func (*base) ShimEnv(_ string) []string {
+	return nil
+}
+
 func (*base) Untar(tarball string, targetDir string) error {
 	return Untar(tarball, targetDir)
 }
@@ -0,0 +1,112 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type Bun struct {
+	*base
+	Root string
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "bun",
+		Description: "Bun from github.com/oven-sh/bun",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Bun{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+var bunOSArch = &OSArch{
+	Linux:  "linux",
+	Darwin: "darwin",
+	AMD64:  "x64",
+	ARM64:  "aarch64",
+}
+
+const bunURL = "https://github.com/oven-sh/bun"
+
+// version, os, arch
+const bunAssetURL = "https://github.com/oven-sh/bun/releases/download/bun-v%s/bun-%s-%s.zip"
+
+func (b *Bun) List(ctx context.Context, all bool) ([]string, error) {
+	g := b.github()
+	tags, err := g.Tags(ctx, bunURL)
+	if err != nil {
+		return nil, err
+	}
+	stable := regexp.MustCompile(`^bun-v(\d+\.\d+\.\d+)$`)
+	var out []string
+	for _, tag := range tags {
+		if m := stable.FindStringSubmatch(tag); m != nil {
+			out = append(out, m[1])
+		}
+	}
+	if !all && len(out) > 10 {
+		out = out[:10]
+	}
+	return out, nil
+}
+
+func (b *Bun) Latest(ctx context.Context) (string, error) {
+	out, err := b.List(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("not found")
+	}
+	return out[0], nil
+}
+
+func (b *Bun) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := b.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	version = strings.TrimPrefix(version, "v")
+	targetDir := filepath.Join(b.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	url := b.url(fmt.Sprintf(bunAssetURL, version, bunOSArch.OS(), bunOSArch.Arch()))
+	cacheFile := filepath.Join(b.Root, "cache", version+".zip")
+	if err := os.MkdirAll(filepath.Join(b.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := b.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := b.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (b *Bun) BinDirs() []string {
+	return []string{"."}
+}
+
+// bun behaves as bunx when it is invoked via a bunx symlink, which the zip does not contain
+func (b *Bun) Untar(cacheFile string, targetDir string) error {
+	if err := Unzip(cacheFile, targetDir); err != nil {
+		return err
+	}
+	return os.Symlink("bun", filepath.Join(targetDir, "bunx"))
+}
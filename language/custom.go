@@ -0,0 +1,187 @@
+package language
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/skaji/tinyenv/config"
+	"golang.org/x/mod/semver"
+)
+
+// Custom is a language defined in config.json
+type Custom struct {
+	*base
+	Root string
+	Def  *config.Language
+}
+
+func (c *Custom) osArch() *OSArch {
+	get := func(key string, def string) string {
+		if v, ok := c.Def.OSArch[key]; ok {
+			return v
+		}
+		return def
+	}
+	return &OSArch{
+		Linux:  get("linux", "linux"),
+		Darwin: get("darwin", "darwin"),
+		AMD64:  get("amd64", "amd64"),
+		ARM64:  get("arm64", "arm64"),
+	}
+}
+
+func (c *Custom) List(ctx context.Context, all bool) ([]string, error) {
+	b, err := c.get(ctx, c.url(c.Def.Versions.URL))
+	if err != nil {
+		return nil, err
+	}
+	var found []string
+	if c.Def.Versions.Regexp != nil {
+		for _, m := range c.Def.Versions.Regexp.FindAllStringSubmatch(string(b), -1) {
+			found = append(found, m[len(m)-1])
+		}
+	} else {
+		var data any
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, err
+		}
+		found = jsonPath(data, strings.Split(c.Def.Versions.JSON, "."))
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, version := range found {
+		if version != "" && !seen[version] {
+			out = append(out, version)
+			seen[version] = true
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no versions in " + c.Def.Versions.URL)
+	}
+	slices.SortStableFunc(out, func(v1, v2 string) int {
+		if !strings.HasPrefix(v1, "v") {
+			v1 = "v" + v1
+		}
+		if !strings.HasPrefix(v2, "v") {
+			v2 = "v" + v2
+		}
+		return semver.Compare(v2, v1)
+	})
+	if !all && len(out) > 10 {
+		out = out[:10]
+	}
+	return out, nil
+}
+
+func jsonPath(data any, path []string) []string {
+	if len(path) == 0 || len(path) == 1 && path[0] == "" {
+		switch v := data.(type) {
+		case string:
+			return []string{v}
+		case float64:
+			return []string{fmt.Sprint(v)}
+		}
+		return nil
+	}
+	key, rest := path[0], path[1:]
+	var out []string
+	switch v := data.(type) {
+	case []any:
+		if key == "*" {
+			for _, e := range v {
+				out = append(out, jsonPath(e, rest)...)
+			}
+		}
+	case map[string]any:
+		switch key {
+		case "*":
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				out = append(out, jsonPath(v[k], rest)...)
+			}
+		case "#":
+			out = slices.Sorted(maps.Keys(v))
+		default:
+			out = jsonPath(v[key], rest)
+		}
+	}
+	return out
+}
+
+func (c *Custom) Latest(ctx context.Context) (string, error) {
+	out, err := c.List(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("not found")
+	}
+	return out[0], nil
+}
+
+func (c *Custom) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := c.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	targetDir := filepath.Join(c.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	osArch := c.osArch()
+	url := strings.NewReplacer(
+		"{version}", version,
+		"{os}", osArch.OS(),
+		"{arch}", osArch.Arch(),
+	).Replace(c.Def.Asset)
+	url = c.url(url)
+	ext := ".tar.gz"
+	switch {
+	case strings.HasSuffix(url, ".zip"):
+		ext = ".zip"
+	case strings.HasSuffix(url, ".tar.xz"), strings.HasSuffix(url, ".txz"):
+		ext = ".tar.xz"
+	}
+	cacheFile := filepath.Join(c.Root, "cache", version+ext)
+	if err := os.MkdirAll(filepath.Join(c.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := c.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := c.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (c *Custom) BinDirs() []string {
+	if len(c.Def.BinDirs) == 0 {
+		return []string{"bin"}
+	}
+	return c.Def.BinDirs
+}
+
+func (c *Custom) Untar(cacheFile string, targetDir string) error {
+	strip := 1
+	if c.Def.Strip != nil {
+		strip = *c.Def.Strip
+	}
+	if strings.HasSuffix(cacheFile, ".zip") {
+		return UnzipStrip(cacheFile, targetDir, strip)
+	}
+	return UntarStrip(cacheFile, targetDir, strip)
+}
@@ -0,0 +1,98 @@
+package language
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/skaji/tinyenv/config"
+)
+
+func TestJSONPath(t *testing.T) {
+	var data any = map[string]any{
+		"releases": []any{
+			map[string]any{"version": "1.0.0"},
+			map[string]any{"version": "1.1.0"},
+		},
+		"versions": map[string]any{
+			"2.0.0": map[string]any{},
+			"2.1.0": map[string]any{},
+		},
+	}
+	if got := jsonPath(data, []string{"releases", "*", "version"}); !slices.Equal(got, []string{"1.0.0", "1.1.0"}) {
+		t.Errorf("releases.*.version = %v", got)
+	}
+	if got := jsonPath(data, []string{"versions", "#"}); !slices.Equal(got, []string{"2.0.0", "2.1.0"}) {
+		t.Errorf("versions.# = %v", got)
+	}
+	if got := jsonPath(data, []string{"missing", "*"}); len(got) != 0 {
+		t.Errorf("missing.* = %v", got)
+	}
+}
+
+func TestCustom(t *testing.T) {
+	var archive bytes.Buffer
+	w := zip.NewWriter(&archive)
+	h := &zip.FileHeader{Name: "tool"}
+	h.SetMode(0o755)
+	fw, err := w.CreateHeader(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fw.Write([]byte("#!/bin/sh\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	asset := "/tool-1.10.0-" + runtime.GOOS + "-" + runtime.GOARCH + ".zip"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/index.html":
+			_, _ = w.Write([]byte(`<a href="tool-1.9.0.zip">, <a href="tool-1.10.0.zip">, <a href="tool-1.2.0.zip">`))
+		case asset:
+			_, _ = w.Write(archive.Bytes())
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	strip := 0
+	c := &Custom{
+		Root: t.TempDir(),
+		Def: &config.Language{
+			Versions: &config.Versions{
+				URL:    server.URL + "/index.html",
+				Regexp: regexp.MustCompile(`tool-([\d.]+)\.zip`),
+			},
+			Asset:   server.URL + "/tool-{version}-{os}-{arch}.zip",
+			Strip:   &strip,
+			BinDirs: []string{"."},
+		},
+	}
+	versions, err := c.List(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(versions, []string{"1.10.0", "1.9.0", "1.2.0"}) {
+		t.Errorf("versions = %v", versions)
+	}
+	version, err := c.Install(context.Background(), "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "1.10.0" {
+		t.Errorf("version = %s", version)
+	}
+	if info, err := os.Stat(filepath.Join(c.Root, "versions", version, "tool")); err != nil || info.Mode()&0o111 == 0 {
+		t.Errorf("tool is not installed: %v", err)
+	}
+}
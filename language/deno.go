@@ -0,0 +1,108 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type Deno struct {
+	*base
+	Root string
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "deno",
+		Description: "Deno from github.com/denoland/deno",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Deno{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+var denoOSArch = &OSArch{
+	Linux:  "unknown-linux-gnu",
+	Darwin: "apple-darwin",
+	AMD64:  "x86_64",
+	ARM64:  "aarch64",
+}
+
+const denoURL = "https://github.com/denoland/deno"
+
+// version, arch, os
+const denoAssetURL = "https://github.com/denoland/deno/releases/download/v%s/deno-%s-%s.zip"
+
+func (d *Deno) List(ctx context.Context, all bool) ([]string, error) {
+	g := d.github()
+	tags, err := g.Tags(ctx, denoURL)
+	if err != nil {
+		return nil, err
+	}
+	stable := regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+	var out []string
+	for _, tag := range tags {
+		if stable.MatchString(tag) {
+			out = append(out, strings.TrimPrefix(tag, "v"))
+		}
+	}
+	if !all && len(out) > 10 {
+		out = out[:10]
+	}
+	return out, nil
+}
+
+func (d *Deno) Latest(ctx context.Context) (string, error) {
+	out, err := d.List(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("not found")
+	}
+	return out[0], nil
+}
+
+func (d *Deno) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := d.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	version = strings.TrimPrefix(version, "v")
+	targetDir := filepath.Join(d.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	url := d.url(fmt.Sprintf(denoAssetURL, version, denoOSArch.Arch(), denoOSArch.OS()))
+	cacheFile := filepath.Join(d.Root, "cache", version+".zip")
+	if err := os.MkdirAll(filepath.Join(d.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := d.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := d.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (d *Deno) BinDirs() []string {
+	return []string{"."}
+}
+
+func (d *Deno) Untar(cacheFile string, targetDir string) error {
+	return UnzipStrip(cacheFile, targetDir, 0)
+}
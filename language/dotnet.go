@@ -0,0 +1,228 @@
+package language
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type Dotnet struct {
+	*base
+	Root string
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "dotnet",
+		Description: ".NET SDK from builds.dotnet.microsoft.com",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Dotnet{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+var dotnetOSArch = &OSArch{
+	Linux:  "linux",
+	Darwin: "osx",
+	AMD64:  "x64",
+	ARM64:  "arm64",
+}
+
+const dotnetVersionsURL = "https://builds.dotnet.microsoft.com/dotnet/release-metadata/releases-index.json"
+
+type dotnetChannel struct {
+	ChannelVersion string `json:"channel-version"`
+	LatestSDK      string `json:"latest-sdk"`
+	SupportPhase   string `json:"support-phase"`
+	ReleaseType    string `json:"release-type"`
+	ReleasesJSON   string `json:"releases.json"`
+}
+
+type dotnetSDK struct {
+	Version string `json:"version"`
+	Files   []struct {
+		Name string `json:"name"`
+		RID  string `json:"rid"`
+		URL  string `json:"url"`
+		Hash string `json:"hash"`
+	} `json:"files"`
+}
+
+func (d *Dotnet) channels(ctx context.Context) ([]*dotnetChannel, error) {
+	b, err := d.get(ctx, d.url(dotnetVersionsURL))
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		ReleasesIndex []*dotnetChannel `json:"releases-index"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res.ReleasesIndex, nil
+}
+
+func (d *Dotnet) sdks(ctx context.Context, channel *dotnetChannel) ([]*dotnetSDK, error) {
+	b, err := d.get(ctx, d.url(channel.ReleasesJSON))
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Releases []struct {
+			SDKs []*dotnetSDK `json:"sdks"`
+		} `json:"releases"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	var out []*dotnetSDK
+	seen := map[string]bool{}
+	for _, release := range res.Releases {
+		for _, sdk := range release.SDKs {
+			if !seen[sdk.Version] {
+				out = append(out, sdk)
+				seen[sdk.Version] = true
+			}
+		}
+	}
+	return out, nil
+}
+
+func (d *Dotnet) List(ctx context.Context, all bool) ([]string, error) {
+	channels, err := d.channels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		var out []string
+		for _, channel := range channels {
+			if channel.SupportPhase != "preview" {
+				out = append(out, channel.LatestSDK)
+			}
+		}
+		if len(out) > 10 {
+			out = out[:10]
+		}
+		return out, nil
+	}
+
+	sdks := make([][]*dotnetSDK, len(channels))
+	var group errgroup.Group
+	for i, channel := range channels {
+		group.Go(func() error {
+			s, err := d.sdks(ctx, channel)
+			if err != nil {
+				return err
+			}
+			sdks[i] = s
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, s := range sdks {
+		for _, sdk := range s {
+			out = append(out, sdk.Version)
+		}
+	}
+	return out, nil
+}
+
+func (d *Dotnet) Latest(ctx context.Context) (string, error) {
+	channels, err := d.channels(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, channel := range channels {
+		if channel.ReleaseType == "lts" && channel.SupportPhase == "active" {
+			return channel.LatestSDK, nil
+		}
+	}
+	return "", errors.New("not found")
+}
+
+func (d *Dotnet) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := d.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	targetDir := filepath.Join(d.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	channels, err := d.channels(ctx)
+	if err != nil {
+		return "", err
+	}
+	index := slices.IndexFunc(channels, func(c *dotnetChannel) bool {
+		return strings.HasPrefix(version, c.ChannelVersion+".")
+	})
+	if index == -1 {
+		return "", errors.New("invalid version: " + version)
+	}
+	sdks, err := d.sdks(ctx, channels[index])
+	if err != nil {
+		return "", err
+	}
+	var url, hash string
+	rid := dotnetOSArch.OS() + "-" + dotnetOSArch.Arch()
+	for _, sdk := range sdks {
+		if sdk.Version != version {
+			continue
+		}
+		for _, file := range sdk.Files {
+			if file.RID == rid && strings.HasSuffix(file.Name, ".tar.gz") {
+				url, hash = file.URL, file.Hash
+			}
+		}
+	}
+	if url == "" {
+		return "", fmt.Errorf("no %s tarball for %s", rid, version)
+	}
+	url = d.url(url)
+
+	cacheFile := filepath.Join(d.Root, "cache", version+".tar.gz")
+	if err := os.MkdirAll(filepath.Join(d.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := d.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	if err := VerifySHA512(cacheFile, hash); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := d.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// dotnet sits at the top of the tarball
+func (d *Dotnet) BinDirs() []string {
+	return []string{"."}
+}
+
+func (d *Dotnet) ShimEnv(versionDir string) []string {
+	return []string{"DOTNET_ROOT=" + versionDir}
+}
+
+func (d *Dotnet) Untar(cacheFile string, targetDir string) error {
+	return UntarStrip(cacheFile, targetDir, 0)
+}
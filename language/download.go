@@ -0,0 +1,62 @@
+package language
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Download struct {
+	URL    string
+	File   string
+	SHA256 string
+}
+
+// DownloadRecorder records what HTTPMirror downloads with a context from WithDownloadRecorder.
+// Use a DownloadRecorder for the install of one version.
+// If ExpectedSHA256 is set, the download is verified before it is extracted,
+// wherever it is downloaded from; a mirror may move an archive to another URL.
+type DownloadRecorder struct {
+	ExpectedSHA256 string
+
+	mu        sync.Mutex
+	downloads []*Download
+}
+
+type downloadRecorderKey struct{}
+
+func WithDownloadRecorder(ctx context.Context, r *DownloadRecorder) context.Context {
+	return context.WithValue(ctx, downloadRecorderKey{}, r)
+}
+
+func downloadRecorderFrom(ctx context.Context) *DownloadRecorder {
+	r, _ := ctx.Value(downloadRecorderKey{}).(*DownloadRecorder)
+	return r
+}
+
+func (r *DownloadRecorder) record(url string, file string) error {
+	sha256, err := SHA256File(file)
+	if err != nil {
+		return err
+	}
+	if r.ExpectedSHA256 != "" && !strings.EqualFold(sha256, r.ExpectedSHA256) {
+		os.Remove(file)
+		return fmt.Errorf("sha256 mismatch for %s: expected %s, got %s", url, r.ExpectedSHA256, sha256)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.downloads = append(r.downloads, &Download{URL: url, File: file, SHA256: sha256})
+	return nil
+}
+
+// Last returns the last download, or nil
+func (r *DownloadRecorder) Last() *Download {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.downloads) == 0 {
+		return nil
+	}
+	return r.downloads[len(r.downloads)-1]
+}
@@ -2,14 +2,41 @@ package language
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
-type GitHub struct{}
+// GitHub scrapes the releases of a repository such as "https://github.com/denoland/deno".
+// Its Options replace the base URLs of the repository and the assets.
+type GitHub struct {
+	Options *Options
+}
 
+// Tags returns the tags on the first page of releases, that is, the newest ones.
 func (g *GitHub) Tags(ctx context.Context, url string) ([]string, error) {
-	b, err := HTTPGet(ctx, url+"/releases")
+	return g.tags(ctx, url+"/releases")
+}
+
+// AllTags follows the pages of releases until a page has no new tags, reading at most maxPages pages.
+func (g *GitHub) AllTags(ctx context.Context, url string, maxPages int) ([]string, error) {
+	var out []string
+	for page := 1; page <= maxPages; page++ {
+		tags, err := g.tags(ctx, fmt.Sprintf("%s/releases?page=%d", url, page))
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) == 0 || slices.Contains(out, tags[0]) {
+			break
+		}
+		out = append(out, tags...)
+	}
+	return out, nil
+}
+
+func (g *GitHub) tags(ctx context.Context, url string) ([]string, error) {
+	b, err := g.Options.Get(ctx, g.Options.URL(url))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +53,7 @@ func (g *GitHub) Tags(ctx context.Context, url string) ([]string, error) {
 }
 
 func (g *GitHub) Assets(ctx context.Context, url string, tag string) ([]string, error) {
-	b, err := HTTPGet(ctx, url+"/releases/expanded_assets/"+tag)
+	b, err := g.Options.Get(ctx, g.Options.URL(url+"/releases/expanded_assets/"+tag))
 	if err != nil {
 		return nil, err
 	}
@@ -36,9 +63,9 @@ func (g *GitHub) Assets(ctx context.Context, url string, tag string) ([]string,
 		href := match[1]
 		if strings.Contains(href, "/releases/download/") {
 			if strings.HasPrefix(href, "https") {
-				out = append(out, href)
+				out = append(out, g.Options.URL(href))
 			} else {
-				out = append(out, "https://github.com"+href)
+				out = append(out, g.Options.URL("https://github.com"+href))
 			}
 		}
 	}
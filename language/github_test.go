@@ -2,25 +2,62 @@ package language
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 )
 
 func TestGitHub(t *testing.T) {
-	g := &GitHub{}
+	server := fakePythonServer(t)
+	g := &GitHub{Options: fakeOptions(server, map[string]string{"https://github.com": ""})}
 
 	tags, err := g.Tags(context.Background(), "https://github.com/astral-sh/python-build-standalone")
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, tag := range tags {
-		t.Log(tag)
+	if want := []string{"20241206", "20241205"}; !slices.Equal(tags, want) {
+		t.Errorf("Tags() = %v, want %v", tags, want)
 	}
 
 	assets, err := g.Assets(context.Background(), "https://github.com/astral-sh/python-build-standalone", tags[0])
 	if err != nil {
 		t.Fatal(err)
 	}
+	if len(assets) != 4 {
+		t.Errorf("Assets() = %v, want 4 assets", assets)
+	}
 	for _, asset := range assets {
-		t.Log(asset)
+		if !strings.HasPrefix(asset, server.URL+"/astral-sh/python-build-standalone/releases/download/20241206/") {
+			t.Errorf("asset %s is not on the fake server", asset)
+		}
+	}
+}
+
+func TestGitHubAllTags(t *testing.T) {
+	pages := map[string]string{
+		"1": `<a href="/rust-lang/rust/releases/tag/1.83.0">1.83.0</a><a href="/rust-lang/rust/releases/tag/1.82.0">1.82.0</a>`,
+		"2": `<a href="/rust-lang/rust/releases/tag/1.81.0">1.81.0</a>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(pages[req.URL.Query().Get("page")]))
+	}))
+	t.Cleanup(server.Close)
+	g := &GitHub{Options: fakeOptions(server, map[string]string{"https://github.com": ""})}
+
+	tags, err := g.AllTags(context.Background(), "https://github.com/rust-lang/rust", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.83.0", "1.82.0", "1.81.0"}; !slices.Equal(tags, want) {
+		t.Errorf("AllTags() = %v, want %v", tags, want)
+	}
+	tags, err = g.AllTags(context.Background(), "https://github.com/rust-lang/rust", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.83.0", "1.82.0"}; !slices.Equal(tags, want) {
+		t.Errorf("AllTags() with 1 page = %v, want %v", tags, want)
 	}
 }
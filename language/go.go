@@ -16,6 +16,17 @@ type Go struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "go",
+		Description: "Go from go.dev",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Go{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 var goOSArch = &OSArch{
 	Linux:  "linux",
 	Darwin: "darwin",
@@ -23,11 +34,13 @@ var goOSArch = &OSArch{
 	ARM64:  "arm64",
 }
 
+const goVersionsURL = "https://go.dev/dl/?mode=json&include=all"
+
 // version, os, arch
 const goAssetURL = "https://dl.google.com/go/go%s.%s-%s.tar.gz"
 
 func (g *Go) List(ctx context.Context, all bool) ([]string, error) {
-	b, err := HTTPGet(ctx, "https://go.dev/dl/?mode=json&include=all")
+	b, err := g.get(ctx, g.url(goVersionsURL))
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +63,7 @@ func (g *Go) List(ctx context.Context, all bool) ([]string, error) {
 				out2 = append(out2, v)
 			}
 		}
-		return out2[:10], nil
+		return out2[:min(10, len(out2))], nil
 	}
 	return out, nil
 }
@@ -79,17 +92,17 @@ func (g *Go) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := fmt.Sprintf(goAssetURL, version, goOSArch.OS(), goOSArch.Arch())
+	url := g.url(fmt.Sprintf(goAssetURL, version, goOSArch.OS(), goOSArch.Arch()))
 	cacheFile := filepath.Join(g.Root, "cache", version+".tar.gz")
 	if err := os.MkdirAll(filepath.Join(g.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := g.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := g.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
@@ -0,0 +1,21 @@
+package language
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	asset := fmt.Sprintf("/dl/go1.23.4.%s-%s.tar.gz", goOSArch.OS(), goOSArch.Arch())
+	server := fakeServer(t, map[string]string{
+		"/dev/dl/": `[{"version": "go1.24rc1"}, {"version": "go1.23.4"}, {"version": "go1.22.10"}]`,
+		asset:      fakeTarball(t, "go/bin/go", "go/bin/gofmt"),
+	})
+	root := t.TempDir()
+	g := &Go{
+		base: newBase(fakeOptions(server, map[string]string{"https://go.dev": "/dev", "https://dl.google.com/go": "/dl"})),
+		Root: root,
+	}
+	testSpecific(t, g, root, []string{"1.24rc1", "1.23.4", "1.22.10"}, "1.23.4", "latest", "1.23.4", filepath.Join("bin", "go"))
+}
@@ -0,0 +1,43 @@
+package language
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// RunHooks runs the commands configured for event (post-install, post-global, post-uninstall).
+// A failing hook is only reported unless it is marked as fatal.
+func (l *Language) RunHooks(event string, version string) error {
+	if l.Config == nil {
+		return nil
+	}
+	hooks := l.Config.Hooks[l.Name].Get(event)
+	if len(hooks) == 0 {
+		return nil
+	}
+	env := append(os.Environ(),
+		"TINYENV_ROOT="+filepath.Dir(l.Root),
+		"TINYENV_HOOK="+event,
+		"TINYENV_LANGUAGE="+l.Name,
+		"TINYENV_VERSION="+version,
+		"TINYENV_VERSION_DIR="+filepath.Join(l.Root, "versions", version),
+	)
+	for _, hook := range hooks {
+		fmt.Println("---> Running " + event + " hook: " + hook.Command)
+		cmd := exec.Command("/bin/sh", "-c", hook.Command)
+		cmd.Env = env
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			err = fmt.Errorf("%s hook %q failed: %w", event, hook.Command, err)
+			if hook.Fatal {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	return nil
+}
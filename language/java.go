@@ -22,6 +22,17 @@ type Java struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "java",
+		Description: "Eclipse Temurin JDK from adoptium.net",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Java{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 var javaOSArch = &OSArch{
 	Linux:  "linux",
 	Darwin: "mac",
@@ -49,9 +60,9 @@ func (j *Java) list(ctx context.Context, onlyLTS bool, loops int) ([]string, err
 		if onlyLTS {
 			q.Set("lts", "true")
 		}
-		u := javaVersionsURL + "?" + q.Encode()
+		u := j.url(javaVersionsURL) + "?" + q.Encode()
 		group.Go(func() error {
-			body, err := HTTPGet(ctx, u)
+			body, err := j.get(ctx, u)
 			if err != nil {
 				if strings.HasPrefix(err.Error(), "404") {
 					return nil
@@ -139,21 +150,21 @@ func (j *Java) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := fmt.Sprintf(javaAssetURL,
+	url := j.url(fmt.Sprintf(javaAssetURL,
 		"jdk-"+strings.TrimPrefix(version, "temurin-"),
 		javaOSArch.OS(),
-		javaOSArch.Arch())
+		javaOSArch.Arch()))
 	cacheFile := filepath.Join(j.Root, "cache", version+".tar.gz")
 	if err := os.MkdirAll(filepath.Join(j.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := j.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := j.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
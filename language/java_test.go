@@ -0,0 +1,36 @@
+package language
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+func TestJava(t *testing.T) {
+	top := "jdk-21.0.5+11"
+	if javaOSArch.OS() == "mac" {
+		top = path.Join(top, "Contents", "Home")
+	}
+	tarball := fakeTarball(t, path.Join(top, "bin", "java"), path.Join(top, "bin", "javac"))
+	asset := fmt.Sprintf("/v3/binary/version/jdk-21.0.5+11/%s/%s/jdk/hotspot/normal/eclipse", javaOSArch.OS(), javaOSArch.Arch())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == asset:
+			_, _ = w.Write([]byte(tarball))
+		case req.URL.Path != "/v3/info/release_names" || req.URL.Query().Get("page") != "0":
+			http.NotFound(w, req)
+		case req.URL.Query().Get("lts") == "true":
+			_, _ = w.Write([]byte(`{"releases": ["jdk-21.0.5+11", "jdk-17.0.13+11"]}`))
+		default:
+			_, _ = w.Write([]byte(`{"releases": ["jdk-23.0.1+11", "jdk-21.0.5+11", "jdk-17.0.13+11"]}`))
+		}
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	j := &Java{base: newBase(fakeOptions(server, map[string]string{"https://api.adoptium.net": ""})), Root: root}
+	testSpecific(t, j, root, []string{"temurin-23.0.1+11", "temurin-21.0.5+11", "temurin-17.0.13+11"}, "temurin-21.0.5+11", "latest", "temurin-21.0.5+11", filepath.Join("bin", "java"))
+}
@@ -0,0 +1,26 @@
+package language
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// the Scala 2.13 builds, which every release since 2.4.0 ships
+var kafkaDist = &ApacheDist{
+	Paths: []string{"kafka/"},
+	// <a href="3.9.0/">3.9.0/</a>, skipping the releases before 2.4.0, which have no Scala 2.13 build
+	Version: regexp.MustCompile(`<a href="((?:2\.(?:[4-9]|\d\d)|[3-9]|\d\d)\.[\d.]+)/">`),
+	Asset: func(version string) string {
+		return fmt.Sprintf("kafka/%s/kafka_2.13-%s.tgz", version, version)
+	},
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "kafka",
+		Description: "Apache Kafka from archive.apache.org",
+		New: func(root string, opts *Options) Specific {
+			return &Apache{base: newBase(opts), Root: root, Dist: kafkaDist}
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package language
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestKafkaList(t *testing.T) {
+	server := fakeServer(t, map[string]string{
+		"/archive/kafka/": `<a href="0.10.2.2/">0.10.2.2/</a> <a href="2.3.1/">2.3.1/</a> <a href="2.4.0/">2.4.0/</a> <a href="2.8.2/">2.8.2/</a> <a href="3.9.0/">3.9.0/</a> <a href="4.0.0/">4.0.0/</a>`,
+	})
+	a := &Apache{
+		base: newBase(fakeOptions(server, map[string]string{"https://archive.apache.org/dist/": "/archive/"})),
+		Root: t.TempDir(),
+		Dist: kafkaDist,
+	}
+	got, err := a.List(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"4.0.0", "3.9.0", "2.8.2", "2.4.0"}; !slices.Equal(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
@@ -5,30 +5,58 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/skaji/tinyenv/config"
 	"golang.org/x/mod/semver"
 )
 
-var All = []string{
-	"go",
-	"java",
-	"node",
-	"perl",
-	"python",
-	"raku",
-	"ruby",
-	"solr",
+// Reserved is the names that cannot be languages, because tinyenv handles them first.
+// The tinyenv command sets it to its global commands.
+var Reserved []string
+
+// CheckName returns an error if name cannot be a language.
+// name is a directory in the root, and appears in LANGUAGE@VERSION arguments.
+func CheckName(name string) error {
+	if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\@`) || name == "." || name == "bin" || name == "plugins" {
+		return fmt.Errorf("invalid language name %q", name)
+	}
+	if slices.Contains(Reserved, name) {
+		return fmt.Errorf("%s is a command of tinyenv, so it cannot be a language", name)
+	}
+	return nil
+}
+
+// Names returns All, the languages defined in cfg, and plugins
+func Names(root string, cfg *config.Config) []string {
+	out := slices.Clone(All)
+	if cfg != nil {
+		for _, name := range slices.Sorted(maps.Keys(cfg.Languages)) {
+			if !slices.Contains(out, name) {
+				out = append(out, name)
+			}
+		}
+	}
+	for _, name := range PluginNames(root) {
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
 }
 
 type Language struct {
 	Name   string
 	Root   string
 	Config *config.Config
+	// nil means http.DefaultClient and the public endpoints
+	Options *Options
 }
 
 type Specific interface {
@@ -36,42 +64,77 @@ type Specific interface {
 	Latest(ctx context.Context) (string, error)
 	Install(ctx context.Context, version string) (string, error)
 	BinDirs() []string
+	ShimEnv(versionDir string) []string
 	Untar(tarball string, targetDir string) error
 }
 
-func (l *Language) Specific() Specific {
-	switch l.Name {
-	case "go":
-		return &Go{Root: l.Root}
-	case "java":
-		return &Java{Root: l.Root}
-	case "node":
-		return &Node{Root: l.Root}
-	case "perl":
-		return &Perl{Root: l.Root}
-	case "python":
-		return &Python{Root: l.Root}
-	case "raku":
-		return &Raku{Root: l.Root}
-	case "ruby":
-		return &Ruby{Root: l.Root}
-	case "solr":
-		return &Solr{Root: l.Root}
-	default:
-		panic("unknown language: " + l.Name)
+func (l *Language) Specific() (Specific, error) {
+	if def, ok := Lookup(l.Name); ok {
+		if !def.Supported() {
+			return nil, fmt.Errorf("%s is not supported on %s/%s", l.Name, runtime.GOOS, runtime.GOARCH)
+		}
+		return def.New(l.Root, l.Options), nil
 	}
+	if l.Config != nil {
+		if def, ok := l.Config.Languages[l.Name]; ok {
+			return &Custom{base: newBase(l.Options), Root: l.Root, Def: def}, nil
+		}
+	}
+	if path, ok := FindPlugin(filepath.Dir(l.Root), l.Name); ok {
+		return &Plugin{Root: l.Root, Path: path}, nil
+	}
+	return nil, errors.New("unknown language: " + l.Name)
 }
 
 func (l *Language) List(ctx context.Context, all bool) ([]string, error) {
-	return l.Specific().List(ctx, all)
+	specific, err := l.Specific()
+	if err != nil {
+		return nil, err
+	}
+	return specific.List(ctx, all)
+}
+
+// CachedListFile is the file in cache/ where CachedList keeps versions
+const CachedListFile = "list.txt"
+
+// CachedList is List(ctx, false) cached in cache/list.txt for maxAge,
+// so that shell completions do not hit the network every time
+func (l *Language) CachedList(ctx context.Context, maxAge time.Duration) ([]string, error) {
+	cacheFile := filepath.Join(l.Root, "cache", CachedListFile)
+	if info, err := os.Stat(cacheFile); err == nil && time.Since(info.ModTime()) < maxAge {
+		if b, err := os.ReadFile(cacheFile); err == nil {
+			return strings.Fields(string(b)), nil
+		}
+	}
+	versions, err := l.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err == nil {
+		_ = os.WriteFile(cacheFile, []byte(strings.Join(versions, "\n")+"\n"), 0o644)
+	}
+	return versions, nil
 }
 
 func (l *Language) Latest(ctx context.Context) (string, error) {
-	return l.Specific().Latest(ctx)
+	specific, err := l.Specific()
+	if err != nil {
+		return "", err
+	}
+	return specific.Latest(ctx)
 }
 
 func (l *Language) Install(ctx context.Context, version string) (string, error) {
-	return l.Specific().Install(ctx, version)
+	specific, err := l.Specific()
+	if err != nil {
+		return "", err
+	}
+	unlock, err := l.lock(version, errOutput(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	return specific.Install(ctx, version)
 }
 
 func (l *Language) Version() (string, error) {
@@ -121,6 +184,16 @@ func (l *Language) Init() error {
 // Symlinks may not work with `exec "$(dirname "$0")"/perl -x "$0" "$@"` notation
 // So we use shell scripts instead
 func (l *Language) Rehash() error {
+	unlock, err := rootLock(filepath.Dir(l.Root))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	shims, err := l.shims()
+	if err != nil {
+		return err
+	}
+
 	// remove old exeFiles first
 	header := fmt.Sprintf("#!/bin/sh\n# %s\n", l.Name)
 	headerBytes := []byte(header)
@@ -154,21 +227,47 @@ func (l *Language) Rehash() error {
 		}
 	}
 
+	rootBinDir := filepath.Join(filepath.Dir(l.Root), "bin")
+	for _, name := range slices.Sorted(maps.Keys(shims)) {
+		target := filepath.Join(rootBinDir, name)
+		if !l.claim(target) {
+			continue
+		}
+		if err := os.WriteFile(target, []byte(shims[name]), 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shims returns the contents of shims for the current version keyed by their names
+func (l *Language) shims() (map[string]string, error) {
 	version, err := l.Version()
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 
 	var cfg *config.Rehash
 	if l.Config != nil {
 		cfg = l.Config.Rehash[l.Name]
 	}
-	for _, binDir := range l.Specific().BinDirs() {
-		entries, err := os.ReadDir(filepath.Join(l.Root, "versions", version, binDir))
+	specific, err := l.Specific()
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("#!/bin/sh\n# %s\n", l.Name)
+	versionDir := filepath.Join(l.Root, "versions", version)
+	var exports string
+	for _, env := range specific.ShimEnv(versionDir) {
+		key, value, _ := strings.Cut(env, "=")
+		exports += fmt.Sprintf(`export %s="%s"`, key, value) + "\n"
+	}
+	out := map[string]string{}
+	for _, binDir := range specific.BinDirs() {
+		entries, err := os.ReadDir(filepath.Join(versionDir, binDir))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		var exeFiles []string
 		for _, e := range entries {
 			if e.IsDir() {
 				continue
@@ -178,22 +277,60 @@ func (l *Language) Rehash() error {
 			}
 			info, err := e.Info()
 			if err != nil {
-				return err
+				return nil, err
 			}
-			if info.Mode()&0o111 != 0 {
-				exeFiles = append(exeFiles, e.Name())
+			if info.Mode()&0o111 == 0 {
+				continue
 			}
+			source := filepath.Join(versionDir, binDir, e.Name())
+			out[e.Name()] = header + exports + fmt.Sprintf(`exec "%s" "$@"`, source) + "\n"
 		}
-		for _, exeFile := range exeFiles {
-			source := filepath.Join(l.Root, "versions", version, binDir, exeFile)
-			target := filepath.Join(filepath.Dir(l.Root), "bin", exeFile)
-			content := header + fmt.Sprintf(`exec "%s" "$@"`, source) + "\n"
-			if err := os.WriteFile(target, []byte(content), 0o755); err != nil {
-				return err
-			}
+	}
+	return out, nil
+}
+
+// claim reports whether l may write the shim target.
+// If another language already owns it, the priority in config.json decides.
+func (l *Language) claim(target string) bool {
+	if _, err := os.Lstat(target); err != nil {
+		return true
+	}
+	shim, err := ReadShim(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %s is not a tinyenv shim, so %s does not overwrite it\n", target, l.Name)
+		return false
+	}
+	if shim.Language == l.Name {
+		return true
+	}
+	return preferNew(l.Config, filepath.Base(target), shim.Language, l.Name)
+}
+
+// preferNew reports whether the shim name of the language incoming replaces the one of existing
+func preferNew(cfg *config.Config, name string, existing string, incoming string) bool {
+	if cfg.Prefer(existing, incoming) {
+		fmt.Fprintf(os.Stderr, "warning: both %s and %s provide %s, keep %s's (priority in config.json)\n",
+			existing, incoming, name, existing)
+		return false
+	}
+	if cfg.Prefer(incoming, existing) {
+		fmt.Fprintf(os.Stderr, "warning: both %s and %s provide %s, use %s's (priority in config.json)\n",
+			existing, incoming, name, incoming)
+		return true
+	}
+	fmt.Fprintf(os.Stderr, "warning: both %s and %s provide %s, use %s's (set priority in config.json to change it)\n",
+		existing, incoming, name, incoming)
+	return true
+}
+
+// CacheFile returns the downloaded archive of version, or "" if there is none
+func (l *Language) CacheFile(version string) string {
+	for _, ext := range []string{".tar.gz", ".tar.xz", ".zip"} {
+		if f := filepath.Join(l.Root, "cache", version+ext); ExistsFS(f) {
+			return f
 		}
 	}
-	return nil
+	return ""
 }
 
 func (l *Language) Reset(version string) error {
@@ -204,23 +341,29 @@ func (l *Language) Reset(version string) error {
 		}
 		version = current
 	}
+	specific, err := l.Specific()
+	if err != nil {
+		return err
+	}
+	unlock, err := l.lock(version, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	targetDir := filepath.Join(l.Root, "versions", version)
 	if !ExistsFS(targetDir) {
 		return errors.New("invalid version: " + version)
 	}
-	cacheFile := filepath.Join(l.Root, "cache", version+".tar.gz")
-	if !ExistsFS(cacheFile) {
-		cacheFile = filepath.Join(l.Root, "cache", version+".tar.xz")
-		if !ExistsFS(cacheFile) {
-			return errors.New("no cache file for " + version)
-		}
+	cacheFile := l.CacheFile(version)
+	if cacheFile == "" {
+		return errors.New("no cache file for " + version)
 	}
 	fmt.Println("---> Removing " + targetDir)
 	if err := os.RemoveAll(targetDir); err != nil {
 		return err
 	}
 	fmt.Println("---> Extracting " + cacheFile)
-	if err := l.Specific().Untar(cacheFile, targetDir); err != nil {
+	if err := specific.Untar(cacheFile, targetDir); err != nil {
 		return err
 	}
 	if version == current {
@@ -228,3 +371,26 @@ func (l *Language) Reset(version string) error {
 	}
 	return nil
 }
+
+func (l *Language) Uninstall(version string) error {
+	unlock, err := l.lock(version, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	targetDir := filepath.Join(l.Root, "versions", version)
+	if !ExistsFS(targetDir) {
+		return errors.New("invalid version: " + version)
+	}
+	fmt.Println("---> Removing " + targetDir)
+	if err := os.RemoveAll(targetDir); err != nil {
+		return err
+	}
+	if current, _ := l.Version(); version == current {
+		if err := os.Remove(filepath.Join(l.Root, "version")); err != nil {
+			return err
+		}
+		return l.Rehash()
+	}
+	return nil
+}
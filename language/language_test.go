@@ -0,0 +1,135 @@
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/skaji/tinyenv/config"
+)
+
+func installFake(t *testing.T, root string, name string, version string, exeFiles ...string) *Language {
+	t.Helper()
+	l := &Language{Name: name, Root: filepath.Join(root, name)}
+	binDir := filepath.Join(l.Root, "versions", version, "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, exeFile := range exeFiles {
+		if err := os.WriteFile(filepath.Join(binDir, exeFile), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.SetVersion(version); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestRehashCollision(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "bin", "tinyenv"), []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Priority: []string{"perl"}}
+	perl := installFake(t, root, "perl", "relocatable-5.40.0.0", "perl", "prove", "tinyenv")
+	node := installFake(t, root, "node", "v22.12.0", "node", "prove")
+	perl.Config = cfg
+	node.Config = cfg
+
+	owner := func(name string) string {
+		shim, err := ReadShim(filepath.Join(root, "bin", name))
+		if err != nil {
+			return ""
+		}
+		return shim.Language
+	}
+	for _, l := range []*Language{perl, node} {
+		if err := l.Rehash(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := owner("prove"); got != "perl" {
+		t.Errorf("prove is owned by %q, want perl", got)
+	}
+	if got := owner("node"); got != "node" {
+		t.Errorf("node is owned by %q, want node", got)
+	}
+	if b, _ := os.ReadFile(filepath.Join(root, "bin", "tinyenv")); string(b) != "binary" {
+		t.Error("tinyenv was overwritten")
+	}
+
+	// without priority, the last one wins
+	node.Config = nil
+	if err := node.Rehash(); err != nil {
+		t.Fatal(err)
+	}
+	if got := owner("prove"); got != "node" {
+		t.Errorf("prove is owned by %q, want node", got)
+	}
+}
+
+func TestRehashAll(t *testing.T) {
+	root := t.TempDir()
+	binDir := filepath.Join(root, "bin")
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "tinyenv"), []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "stale"), []byte("#!/bin/sh\n# go\nexec \"/nowhere/stale\" \"$@\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goLang := installFake(t, root, "go", "1.23.1", "go", "gofmt")
+	node := installFake(t, root, "node", "v22.12.0", "node", "npm")
+
+	// a broken language leaves bin untouched
+	raku := installFake(t, root, "raku", "2024.12.1", "raku")
+	if err := RehashAll(root, nil, []*Language{goLang, node, raku}); err == nil {
+		t.Fatal("expected an error for raku without share/perl6/site/bin")
+	}
+	if !ExistsFS(filepath.Join(binDir, "stale")) || ExistsFS(filepath.Join(binDir, "go")) {
+		t.Error("bin was changed by a failed rehash")
+	}
+
+	if err := RehashAll(root, nil, []*Language{goLang, node}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"go", "gofmt", "node", "npm", "tinyenv"}; !slices.Equal(names, want) {
+		t.Errorf("bin = %v, want %v", names, want)
+	}
+	if b, _ := os.ReadFile(filepath.Join(binDir, "tinyenv")); string(b) != "binary" {
+		t.Error("tinyenv was overwritten")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(root, ".bin-staging-*")); len(matches) != 0 {
+		t.Errorf("staging directories are left: %v", matches)
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	defer func(reserved []string) { Reserved = reserved }(Reserved)
+	Reserved = []string{"sync", "install"}
+	for _, name := range []string{"terraform", "node-lts", "go_1"} {
+		if err := CheckName(name); err != nil {
+			t.Errorf("CheckName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "../x", "a/b", "/abs", "tool@1", "bin", "plugins", "sync", "install"} {
+		if err := CheckName(name); err == nil {
+			t.Errorf("CheckName(%q) is nil, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package language
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Lock takes an advisory lock on path, which is created if it does not exist.
+// If another process holds the lock, it tells so and waits for it.
+// The returned function releases the lock.
+func Lock(path string) (func(), error) {
+	return lock(path, os.Stderr)
+}
+
+// flock only tells processes apart, so goroutines of this process wait for each other here first
+var (
+	lockMuMu sync.Mutex
+	lockMu   = map[string]*sync.Mutex{}
+)
+
+func processLock(path string) *sync.Mutex {
+	lockMuMu.Lock()
+	defer lockMuMu.Unlock()
+	if _, ok := lockMu[path]; !ok {
+		lockMu[path] = &sync.Mutex{}
+	}
+	return lockMu[path]
+}
+
+// lock is Lock that tells about waiting to w
+func lock(path string, w io.Writer) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	mu := processLock(path)
+	mu.Lock()
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		mu.Unlock()
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			f.Close()
+			mu.Unlock()
+			return nil, err
+		}
+		holder := "another process"
+		if pid := lockHolder(path); pid > 0 {
+			holder = "PID " + strconv.Itoa(pid)
+		}
+		fmt.Fprintf(w, "---> Waiting for lock %s held by %s\n", path, holder)
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+			f.Close()
+			mu.Unlock()
+			return nil, err
+		}
+	}
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+		mu.Unlock()
+	}, nil
+}
+
+func lockHolder(path string) int {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(b)))
+	return pid
+}
+
+// lock is the lock for installing and uninstalling version of l.
+// It is per version, so that different versions of a language can be installed in parallel.
+func (l *Language) lock(version string, w io.Writer) (func(), error) {
+	name := ".lock-" + strings.ReplaceAll(version, string(filepath.Separator), "_")
+	return lock(filepath.Join(l.Root, name), w)
+}
+
+// rootLock is the lock for the shims in root/bin
+func rootLock(root string) (func(), error) {
+	return Lock(filepath.Join(root, ".lock"))
+}
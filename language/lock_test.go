@@ -0,0 +1,70 @@
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", ".lock")
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid := strings.TrimSpace(string(b)); pid != strconv.Itoa(os.Getpid()) {
+		t.Errorf("lock file has %q, want our pid", pid)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlock2, err := Lock(path)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- unlock2
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a lock that is held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case unlock2 := <-acquired:
+		unlock2()
+	case <-time.After(5 * time.Second):
+		t.Fatal("could not acquire a released lock")
+	}
+}
+
+func TestLanguageLockPerVersion(t *testing.T) {
+	l := &Language{Name: "test", Root: t.TempDir()}
+	unlock1, err := l.lock("1.0.0", os.Stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock1()
+
+	acquired := make(chan func())
+	go func() {
+		unlock2, err := l.lock("2.0.0", os.Stderr)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- unlock2
+	}()
+	select {
+	case unlock2 := <-acquired:
+		unlock2()
+	case <-time.After(5 * time.Second):
+		t.Fatal("the lock of 1.0.0 blocks 2.0.0")
+	}
+}
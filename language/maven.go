@@ -0,0 +1,25 @@
+package language
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var mavenDist = &ApacheDist{
+	Paths: []string{"maven/maven-3/"},
+	// <a href="3.9.9/">3.9.9/</a>
+	Version: regexp.MustCompile(`<a href="([\d.]+)/">`),
+	Asset: func(version string) string {
+		return fmt.Sprintf("maven/maven-3/%s/binaries/apache-maven-%s-bin.tar.gz", version, version)
+	},
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "maven",
+		Description: "Apache Maven from archive.apache.org",
+		New: func(root string, opts *Options) Specific {
+			return &Apache{base: newBase(opts), Root: root, Dist: mavenDist}
+		},
+	})
+}
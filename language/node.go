@@ -18,6 +18,17 @@ type Node struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "node",
+		Description: "Node.js from nodejs.org",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Node{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 const nodeVersionsURL = "https://nodejs.org/dist/index.json"
 
 // version, version, os, arch
@@ -53,7 +64,7 @@ func (n *Node) List(ctx context.Context, all bool) ([]string, error) {
 	out := slices.SortedFunc(maps.Values(seen), func(v1, v2 string) int {
 		return semver.Compare(v2, v1)
 	})
-	return out[:10], nil
+	return out[:min(10, len(out))], nil
 }
 
 type nodeAsset struct {
@@ -74,7 +85,7 @@ func (r *nodeAsset) LTS() bool {
 }
 
 func (n *Node) list(ctx context.Context) ([]*nodeAsset, error) {
-	b, err := HTTPGet(ctx, nodeVersionsURL)
+	b, err := n.get(ctx, n.url(nodeVersionsURL))
 	if err != nil {
 		return nil, err
 	}
@@ -114,17 +125,17 @@ func (n *Node) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := fmt.Sprintf(nodeAssetURL, version, version, nodeOSArch.OS(), nodeOSArch.Arch())
+	url := n.url(fmt.Sprintf(nodeAssetURL, version, version, nodeOSArch.OS(), nodeOSArch.Arch()))
 	cacheFile := filepath.Join(n.Root, "cache", version+".tar.xz")
 	if err := os.MkdirAll(filepath.Join(n.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := n.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := n.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
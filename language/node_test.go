@@ -0,0 +1,23 @@
+package language
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestNode(t *testing.T) {
+	asset := fmt.Sprintf("/dist/v22.12.0/node-v22.12.0-%s-%s.tar.xz", nodeOSArch.OS(), nodeOSArch.Arch())
+	server := fakeServer(t, map[string]string{
+		"/dist/index.json": `[
+			{"version": "v20.18.1", "lts": "Iron"},
+			{"version": "v23.4.0", "lts": false},
+			{"version": "v22.12.0", "lts": "Jod"},
+			{"version": "v22.11.0", "lts": "Jod"}
+		]`,
+		asset: fakeTarball(t, "node/bin/node", "node/bin/npm"),
+	})
+	root := t.TempDir()
+	n := &Node{base: newBase(fakeOptions(server, map[string]string{"https://nodejs.org": ""})), Root: root}
+	testSpecific(t, n, root, []string{"v23.4.0", "v22.12.0", "v22.11.0", "v20.18.1"}, "v22.12.0", "latest", "v22.12.0", filepath.Join("bin", "node"))
+}
@@ -0,0 +1,56 @@
+package language
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Options are the HTTP client and the endpoints that languages use.
+// nil and the zero value mean http.DefaultClient and the public endpoints.
+type Options struct {
+	Client *http.Client
+	// BaseURLs replaces the default base URLs of languages, such as
+	// {"https://nodejs.org/dist": "http://127.0.0.1:8080/node"} for a local fake server or an internal mirror.
+	// If several keys match a URL, the longest one is used.
+	BaseURLs map[string]string
+}
+
+func (o *Options) client() *http.Client {
+	if o == nil || o.Client == nil {
+		return http.DefaultClient
+	}
+	return o.Client
+}
+
+// URL returns u with its base URL replaced by BaseURLs
+func (o *Options) URL(u string) string {
+	if o == nil {
+		return u
+	}
+	from := ""
+	for key := range o.BaseURLs {
+		if strings.HasPrefix(u, key) && len(key) > len(from) {
+			from = key
+		}
+	}
+	if from == "" {
+		return u
+	}
+	return o.BaseURLs[from] + strings.TrimPrefix(u, from)
+}
+
+// Get is HTTPGet with the client of o. It does not replace the base URL of url.
+func (o *Options) Get(ctx context.Context, url string) ([]byte, error) {
+	return httpGet(ctx, o.client(), url)
+}
+
+// Head is HTTPHead with the client of o. It does not replace the base URL of url.
+func (o *Options) Head(ctx context.Context, url string) error {
+	return httpHead(ctx, o.client(), url)
+}
+
+// Mirror is HTTPMirror with the client of o. It does not replace the base URL of url.
+func (o *Options) Mirror(ctx context.Context, url string, targetFile string, modifier func(req *http.Request)) error {
+	return httpMirrorRecord(ctx, o.client(), url, targetFile, modifier)
+}
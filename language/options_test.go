@@ -0,0 +1,116 @@
+package language
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOptionsURL(t *testing.T) {
+	o := &Options{BaseURLs: map[string]string{
+		"https://github.com":                        "http://mirror/github",
+		"https://github.com/skaji/relocatable-perl": "http://mirror/perl",
+	}}
+	tests := map[string]string{
+		"https://github.com/denoland/deno/releases":          "http://mirror/github/denoland/deno/releases",
+		"https://github.com/skaji/relocatable-perl/releases": "http://mirror/perl/releases",
+		"https://nodejs.org/dist/index.json":                 "https://nodejs.org/dist/index.json",
+	}
+	for u, want := range tests {
+		if got := o.URL(u); got != want {
+			t.Errorf("URL(%q) = %q, want %q", u, got, want)
+		}
+	}
+	if got := (*Options)(nil).URL("https://go.dev"); got != "https://go.dev" {
+		t.Errorf("nil URL = %q", got)
+	}
+}
+
+// fakeServer serves files by URL path; queries are ignored.
+func fakeServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fakeOptions sends requests for each base URL to server+path
+func fakeOptions(server *httptest.Server, baseURLs map[string]string) *Options {
+	o := &Options{Client: server.Client(), BaseURLs: map[string]string{}}
+	for from, path := range baseURLs {
+		o.BaseURLs[from] = server.URL + path
+	}
+	return o
+}
+
+// fakeTarball returns a gzipped tarball that has executable files under a top directory.
+// tar detects the compression by itself, so it also serves as a .tar.xz file.
+func fakeTarball(t *testing.T, files ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, file := range files {
+		body := []byte("#!/bin/sh\n")
+		if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0o755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// testSpecific checks List, Latest and Install of s against a fake server.
+// Install installs version, and expects the installed version to have exeFile.
+func testSpecific(t *testing.T, s Specific, root string, wantList []string, wantLatest string, version string, wantVersion string, exeFile string) {
+	t.Helper()
+	ctx := context.Background()
+	list, err := s.List(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(list, wantList) {
+		t.Errorf("List() = %v, want %v", list, wantList)
+	}
+	latest, err := s.Latest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != wantLatest {
+		t.Errorf("Latest() = %q, want %q", latest, wantLatest)
+	}
+	installed, err := s.Install(ctx, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed != wantVersion {
+		t.Errorf("Install(%q) = %q, want %q", version, installed, wantVersion)
+	}
+	if path := filepath.Join(root, "versions", installed, exeFile); !ExistsFS(path) {
+		t.Errorf("%s is not installed", path)
+	}
+	if _, err := s.Install(ctx, installed); err == nil {
+		t.Errorf("Install(%q) succeeded twice", installed)
+	}
+}
@@ -14,6 +14,17 @@ type Perl struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "perl",
+		Description: "relocatable-perl from github.com/skaji/relocatable-perl",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Perl{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 var perlOSArch = &OSArch{
 	Linux:  "linux",
 	Darwin: "darwin",
@@ -27,7 +38,7 @@ const perlVersionsURL = "https://raw.githubusercontent.com/skaji/relocatable-per
 const perlAssetURL = "https://github.com/skaji/relocatable-perl/releases/download/%s/perl-%s-%s.tar.xz"
 
 func (p *Perl) List(ctx context.Context, all bool) ([]string, error) {
-	b, err := HTTPGet(ctx, perlVersionsURL)
+	b, err := p.get(ctx, p.url(perlVersionsURL))
 	if err != nil {
 		return nil, err
 	}
@@ -85,17 +96,17 @@ func (p *Perl) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := fmt.Sprintf(perlAssetURL, strings.TrimPrefix(version, "relocatable-"), perlOSArch.OS(), perlOSArch.Arch())
+	url := p.url(fmt.Sprintf(perlAssetURL, strings.TrimPrefix(version, "relocatable-"), perlOSArch.OS(), perlOSArch.Arch()))
 	cacheFile := filepath.Join(p.Root, "cache", version+".tar.xz")
 	if err := os.MkdirAll(filepath.Join(p.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := p.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := p.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
@@ -0,0 +1,26 @@
+package language
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestPerl(t *testing.T) {
+	os, arch := perlOSArch.OS(), perlOSArch.Arch()
+	releases := "version,os,arch,url\n"
+	for _, version := range []string{"5.40.0.1", "5.40.0.0", "5.38.2.1"} {
+		releases += fmt.Sprintf("%s,%s,%s,perl-%s-%s.tar.xz\n", version, os, arch, os, arch)
+		releases += fmt.Sprintf("%s,other,other,perl-other-other.tar.xz\n", version)
+	}
+	server := fakeServer(t, map[string]string{
+		"/raw/skaji/relocatable-perl/main/releases.csv":                                                      releases,
+		fmt.Sprintf("/github/skaji/relocatable-perl/releases/download/5.40.0.0/perl-%s-%s.tar.xz", os, arch): fakeTarball(t, "perl/bin/perl", "perl/bin/prove"),
+	})
+	root := t.TempDir()
+	p := &Perl{
+		base: newBase(fakeOptions(server, map[string]string{"https://raw.githubusercontent.com": "/raw", "https://github.com": "/github"})),
+		Root: root,
+	}
+	testSpecific(t, p, root, []string{"relocatable-5.40.0.1", "relocatable-5.40.0.0", "relocatable-5.38.2.1"}, "relocatable-5.40.0.1", "relocatable-5.40.0.0", "relocatable-5.40.0.0", filepath.Join("bin", "perl"))
+}
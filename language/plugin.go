@@ -0,0 +1,184 @@
+package language
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const pluginPrefix = "tinyenv-lang-"
+
+// Plugin is a language implemented by an external executable named tinyenv-lang-<name>,
+// which is looked up in <root>/plugins and then in PATH.
+//
+// The executable is invoked with a command (list, latest or install) as its argument,
+// a JSON object on stdin, and it must print a JSON object on stdout:
+//
+//	list    {"all": false, "os": "linux", "arch": "amd64", "root": ...}  ->  {"versions": ["1.1.0", "1.0.0"]}
+//	latest  {"os": "linux", "arch": "amd64", "root": ...}                ->  {"version": "1.1.0"}
+//	install {"os": "linux", "arch": "amd64", "root": ..., "version": "1.1.0", "target_dir": ..., "cache_dir": ...}  ->  {}
+//
+// install must populate target_dir so that executables are in target_dir/bin.
+// Progress messages should go to stderr.
+type Plugin struct {
+	*base
+	Root string
+	Path string
+}
+
+type pluginRequest struct {
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Root      string `json:"root"`
+	All       bool   `json:"all,omitempty"`
+	Version   string `json:"version,omitempty"`
+	TargetDir string `json:"target_dir,omitempty"`
+	CacheDir  string `json:"cache_dir,omitempty"`
+}
+
+func (p *Plugin) call(ctx context.Context, command string, req *pluginRequest, res any) error {
+	req.OS = runtime.GOOS
+	req.Arch = runtime.GOARCH
+	req.Root = p.Root
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, p.Path, command)
+	cmd.Stdin = bytes.NewReader(b)
+	cmd.Stderr = errOutput(ctx)
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", p.Path, command, err)
+	}
+	if err := json.Unmarshal(out, res); err != nil {
+		return fmt.Errorf("%s %s: invalid output: %w", p.Path, command, err)
+	}
+	return nil
+}
+
+func (p *Plugin) List(ctx context.Context, all bool) ([]string, error) {
+	var res struct {
+		Versions []string `json:"versions"`
+	}
+	if err := p.call(ctx, "list", &pluginRequest{All: all}, &res); err != nil {
+		return nil, err
+	}
+	return res.Versions, nil
+}
+
+func (p *Plugin) Latest(ctx context.Context) (string, error) {
+	var res struct {
+		Version string `json:"version"`
+	}
+	if err := p.call(ctx, "latest", &pluginRequest{}, &res); err != nil {
+		return "", err
+	}
+	if res.Version == "" {
+		return "", errors.New("not found")
+	}
+	return res.Version, nil
+}
+
+func (p *Plugin) Install(ctx context.Context, version string) (string, error) {
+	if version == "latest" {
+		latest, err := p.Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	}
+	targetDir := filepath.Join(p.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+	cacheDir := filepath.Join(p.Root, "cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Installing "+version+" with "+p.Path)
+	req := &pluginRequest{Version: version, TargetDir: targetDir, CacheDir: cacheDir}
+	var res struct{}
+	if err := p.call(ctx, "install", req, &res); err != nil {
+		os.RemoveAll(targetDir)
+		return "", err
+	}
+	if !ExistsFS(targetDir) {
+		return "", fmt.Errorf("%s install did not create %s", p.Path, targetDir)
+	}
+	return version, nil
+}
+
+// FindPlugin returns the path of tinyenv-lang-<name>
+func FindPlugin(root string, name string) (string, bool) {
+	if CheckName(name) != nil {
+		return "", false
+	}
+	path := filepath.Join(root, "plugins", pluginPrefix+name)
+	if isExecutable(path) {
+		return path, true
+	}
+	if path, err := exec.LookPath(pluginPrefix + name); err == nil {
+		return path, true
+	}
+	return "", false
+}
+
+// PluginNames returns the names of plugins in <root>/plugins and PATH.
+// Plugins whose names cannot be languages are skipped; see InvalidPlugins.
+func PluginNames(root string) []string {
+	names, _ := plugins(root)
+	return names
+}
+
+// InvalidPlugins returns errors for the plugins that PluginNames skips,
+// such as tinyenv-lang-sync, which tinyenv never invokes because sync is a global command.
+func InvalidPlugins(root string) []error {
+	_, errs := plugins(root)
+	return errs
+}
+
+func plugins(root string) ([]string, []error) {
+	var (
+		out  []string
+		errs []error
+	)
+	dirs := append([]string{filepath.Join(root, "plugins")}, filepath.SplitList(os.Getenv("PATH"))...)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), pluginPrefix)
+			if !ok || name == "" || slices.Contains(out, name) {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			if err := CheckName(name); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+				continue
+			}
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out, errs
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode()&0o111 != 0
+}
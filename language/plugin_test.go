@@ -0,0 +1,74 @@
+package language
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testPlugin = `#!/bin/sh
+input=$(cat)
+case "$1" in
+list)
+  echo '{"versions": ["1.1.0", "1.0.0"]}' ;;
+latest)
+  echo '{"version": "1.1.0"}' ;;
+install)
+  target_dir=$(echo "$input" | sed -e 's/.*"target_dir":"\([^"]*\)".*/\1/')
+  mkdir -p "$target_dir/bin" && echo '{}' ;;
+esac
+`
+
+func TestPlugin(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "plugins"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "plugins", "tinyenv-lang-foo"), []byte(testPlugin), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if names := Names(root, nil); !slices.Contains(names, "foo") {
+		t.Fatalf("foo is not in %v", names)
+	}
+
+	l := &Language{Name: "foo", Root: filepath.Join(root, "foo")}
+	versions, err := l.List(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(versions, []string{"1.1.0", "1.0.0"}) {
+		t.Errorf("versions = %v", versions)
+	}
+	version, err := l.Install(context.Background(), "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "1.1.0" || !ExistsFS(filepath.Join(l.Root, "versions", "1.1.0", "bin")) {
+		t.Errorf("1.1.0 is not installed")
+	}
+}
+
+func TestPluginReservedName(t *testing.T) {
+	defer func(reserved []string) { Reserved = reserved }(Reserved)
+	Reserved = []string{"sync"}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "plugins"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"tinyenv-lang-foo", "tinyenv-lang-sync"} {
+		if err := os.WriteFile(filepath.Join(root, "plugins", name), []byte(testPlugin), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if names := PluginNames(root); !slices.Equal(names, []string{"foo"}) {
+		t.Errorf("PluginNames() = %v, want [foo]", names)
+	}
+	if errs := InvalidPlugins(root); len(errs) != 1 {
+		t.Errorf("InvalidPlugins() = %v, want an error for sync", errs)
+	}
+	if _, ok := FindPlugin(root, "sync"); ok {
+		t.Error("FindPlugin() finds sync")
+	}
+}
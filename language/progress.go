@@ -0,0 +1,199 @@
+package language
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress draws one line per download, so that several versions can be downloaded at once.
+// Give it to HTTPMirror with WithProgress.
+// If the output is not a terminal, it only prints a line when a download finishes.
+type Progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	terminal bool
+	bars     []*ProgressBar
+	lines    int
+	drawnAt  time.Time
+}
+
+func NewProgress(f *os.File) *Progress {
+	p := &Progress{out: f}
+	if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		p.terminal = true
+	}
+	return p
+}
+
+type progressKey struct{}
+
+type progressValue struct {
+	progress *Progress
+	label    string
+	out      *progressWriter
+}
+
+// WithProgress makes HTTPMirror draw its downloads in p, labeled with label,
+// and makes installs print their messages above the download lines of p.
+func WithProgress(ctx context.Context, p *Progress, label string) context.Context {
+	return context.WithValue(ctx, progressKey{}, &progressValue{progress: p, label: label, out: &progressWriter{progress: p}})
+}
+
+// Output returns where an install with ctx prints its messages; it is os.Stdout unless ctx comes from WithProgress.
+func Output(ctx context.Context) io.Writer {
+	if v, _ := ctx.Value(progressKey{}).(*progressValue); v != nil {
+		return v.out
+	}
+	return os.Stdout
+}
+
+// errOutput is the os.Stderr version of Output
+func errOutput(ctx context.Context) io.Writer {
+	if v, _ := ctx.Value(progressKey{}).(*progressValue); v != nil {
+		return v.out
+	}
+	return os.Stderr
+}
+
+func progressFrom(ctx context.Context) (*Progress, string) {
+	v, _ := ctx.Value(progressKey{}).(*progressValue)
+	if v == nil {
+		return nil, ""
+	}
+	return v.progress, v.label
+}
+
+// Add adds a line for a download of total bytes; total may be -1 if it is unknown.
+func (p *Progress) Add(label string, total int64) *ProgressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b := &ProgressBar{progress: p, label: label, total: total}
+	p.bars = append(p.bars, b)
+	p.draw(true)
+	return b
+}
+
+// Println prints line above the download lines.
+func (p *Progress) Println(line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.println(line)
+}
+
+func (p *Progress) println(line string) {
+	if !p.terminal {
+		fmt.Fprintln(p.out, line)
+		return
+	}
+	p.clear()
+	fmt.Fprintln(p.out, line)
+	p.draw(true)
+}
+
+func (p *Progress) clear() {
+	if p.lines > 0 {
+		fmt.Fprintf(p.out, "\x1b[%dA", p.lines)
+	}
+	fmt.Fprint(p.out, "\x1b[J")
+	p.lines = 0
+}
+
+func (p *Progress) draw(force bool) {
+	if !p.terminal || (!force && time.Since(p.drawnAt) < 100*time.Millisecond) {
+		return
+	}
+	if p.lines > 0 {
+		fmt.Fprintf(p.out, "\x1b[%dA", p.lines)
+	}
+	for _, b := range p.bars {
+		fmt.Fprint(p.out, "\x1b[2K"+b.String()+"\n")
+	}
+	p.lines = len(p.bars)
+	p.drawnAt = time.Now()
+}
+
+// progressWriter prints each line written to it with Println of Progress.
+type progressWriter struct {
+	progress *Progress
+	mu       sync.Mutex
+	buf      []byte
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, b...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.progress.Println(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(b), nil
+}
+
+// ProgressBar is a line of Progress.
+type ProgressBar struct {
+	progress *Progress
+	label    string
+	total    int64
+	current  int64
+	done     bool
+}
+
+func (b *ProgressBar) Write(buf []byte) (int, error) {
+	b.progress.mu.Lock()
+	defer b.progress.mu.Unlock()
+	b.current += int64(len(buf))
+	b.progress.draw(false)
+	return len(buf), nil
+}
+
+// Close removes the line from the download lines, and prints it as a finished download.
+func (b *ProgressBar) Close() error {
+	p := b.progress
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b.done {
+		return nil
+	}
+	b.done = true
+	for i, bar := range p.bars {
+		if bar == b {
+			p.bars = append(p.bars[:i], p.bars[i+1:]...)
+			break
+		}
+	}
+	p.println(b.String())
+	return nil
+}
+
+func (b *ProgressBar) String() string {
+	if b.total <= 0 {
+		return fmt.Sprintf("%-24s %s", b.label, formatBytes(b.current))
+	}
+	const width = 30
+	n := int(min(b.current, b.total) * width / b.total)
+	return fmt.Sprintf("%-24s %3d%% [%s%s] %s/%s", b.label, min(b.current, b.total)*100/b.total,
+		strings.Repeat("=", n), strings.Repeat(" ", width-n), formatBytes(b.current), formatBytes(b.total))
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f kB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
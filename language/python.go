@@ -18,6 +18,17 @@ type Python struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "python",
+		Description: "CPython from github.com/astral-sh/python-build-standalone",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Python{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 var pythonOSArch = &OSArch{
 	Linux:  "unknown-linux-gnu",
 	Darwin: "apple-darwin",
@@ -31,7 +42,7 @@ const pythonURL = "https://github.com/astral-sh/python-build-standalone"
 const pythonAssetURL = "https://github.com/astral-sh/python-build-standalone/releases/download/%s/cpython-%s+%s-%s-%s-install_only.tar.gz"
 
 func (p *Python) List(ctx context.Context, all bool) ([]string, error) {
-	g := &GitHub{}
+	g := p.github()
 	tags, err := g.Tags(ctx, pythonURL)
 	if err != nil {
 		return nil, err
@@ -97,18 +108,18 @@ func (p *Python) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := fmt.Sprintf(pythonAssetURL,
-		tag, pythonVersion, tag, pythonOSArch.Arch(), pythonOSArch.OS())
+	url := p.url(fmt.Sprintf(pythonAssetURL,
+		tag, pythonVersion, tag, pythonOSArch.Arch(), pythonOSArch.OS()))
 	cacheFile := filepath.Join(p.Root, "cache", version+".tar.gz")
 	if err := os.MkdirAll(filepath.Join(p.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := p.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := p.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
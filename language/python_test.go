@@ -2,14 +2,47 @@ package language
 
 import (
 	"context"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
+// fakePythonServer serves the GitHub release pages of python-build-standalone
+func fakePythonServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	repo := "/astral-sh/python-build-standalone"
+	asset := func(version string, tag string) string {
+		return fmt.Sprintf("%s/releases/download/%s/cpython-%s+%s-%s-%s-install_only.tar.gz", repo, tag, version, tag, pythonOSArch.Arch(), pythonOSArch.OS())
+	}
+	var assets string
+	for _, version := range []string{"3.12.8", "3.13.1", "3.12.8"} {
+		assets += fmt.Sprintf(`<a href="%s">x</a>`+"\n", asset(version, "20241206"))
+	}
+	assets += fmt.Sprintf(`<a href="%s/releases/download/20241206/SHA256SUMS">x</a>`, repo)
+	return fakeServer(t, map[string]string{
+		repo + "/releases":                          fmt.Sprintf(`<a href="%[1]s/releases/tag/20241206">x</a> <a href="%[1]s/releases/tag/20241205">x</a>`, repo),
+		repo + "/releases/expanded_assets/20241206": assets,
+		asset("3.12.8", "20241206"):                 fakeTarball(t, "python/bin/python3", "python/bin/pip3"),
+	})
+}
+
 func TestPythonList(t *testing.T) {
-	p := &Python{}
+	server := fakePythonServer(t)
+	p := &Python{base: newBase(fakeOptions(server, map[string]string{"https://github.com": ""}))}
 	versions, err := p.List(context.Background(), true)
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(versions)
+	if want := []string{"3.13.1+20241206", "3.12.8+20241206"}; !slices.Equal(versions, want) {
+		t.Errorf("List() = %v, want %v", versions, want)
+	}
+}
+
+func TestPython(t *testing.T) {
+	server := fakePythonServer(t)
+	root := t.TempDir()
+	p := &Python{base: newBase(fakeOptions(server, map[string]string{"https://github.com": ""})), Root: root}
+	testSpecific(t, p, root, []string{"3.13.1+20241206", "3.12.8+20241206"}, "3.13.1+20241206", "3.12.8+20241206", "3.12.8+20241206", filepath.Join("bin", "python3"))
 }
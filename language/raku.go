@@ -16,6 +16,17 @@ type Raku struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "raku",
+		Description: "Rakudo from rakudo.org",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Raku{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 var rakuOSArch = &OSArch{
 	Linux:  "linux",
 	Darwin: "macos",
@@ -31,7 +42,7 @@ type rakuAsset struct {
 }
 
 func (r *Raku) list(ctx context.Context) ([]*rakuAsset, error) {
-	body, err := HTTPGet(ctx, rakuVersionsURL)
+	body, err := r.get(ctx, r.url(rakuVersionsURL))
 	if err != nil {
 		return nil, err
 	}
@@ -111,17 +122,17 @@ func (r *Raku) Install(ctx context.Context, version string) (string, error) {
 		return "", errors.New("already exists " + targetDir)
 	}
 
-	url := asset.URL
+	url := r.url(asset.URL)
 	cacheFile := filepath.Join(r.Root, "cache", version+".tar.gz")
 	if err := os.MkdirAll(filepath.Join(r.Root, "cache"), 0o755); err != nil {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := r.mirror(ctx, url, cacheFile, nil); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := r.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
@@ -0,0 +1,24 @@
+package language
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestRaku(t *testing.T) {
+	os, arch := rakuOSArch.OS(), rakuOSArch.Arch()
+	asset := fmt.Sprintf("/dl/rakudo/rakudo-moar-2024.10-01-%s-%s-gcc.tar.gz", os, arch)
+	server := fakeServer(t, map[string]string{
+		"/dl/rakudo": fmt.Sprintf(`[
+			{"type": "archive", "platform": %[1]q, "arch": %[2]q, "ver": "2024.09", "build_rev": 1, "url": "https://rakudo.org/dl/rakudo/old.tar.gz"},
+			{"type": "archive", "platform": %[1]q, "arch": %[2]q, "ver": "2024.10", "build_rev": 1, "url": "https://rakudo.org%[3]s"},
+			{"type": "installer", "platform": %[1]q, "arch": %[2]q, "ver": "2024.10", "build_rev": 1, "url": "https://rakudo.org/dl/rakudo/installer.msi"},
+			{"type": "archive", "platform": "other", "arch": %[2]q, "ver": "2024.12", "build_rev": 1, "url": "https://rakudo.org/dl/rakudo/other.tar.gz"}
+		]`, os, arch, asset),
+		asset: fakeTarball(t, "rakudo/bin/raku", "rakudo/share/perl6/site/bin/zef"),
+	})
+	root := t.TempDir()
+	r := &Raku{base: newBase(fakeOptions(server, map[string]string{"https://rakudo.org": ""})), Root: root}
+	testSpecific(t, r, root, []string{"2024.10.1", "2024.09.1"}, "2024.10.1", "latest", "2024.10.1", filepath.Join("bin", "raku"))
+}
@@ -0,0 +1,72 @@
+package language
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Definition describes a language.
+// Each language registers its Definition in init(),
+// and library users can register their own Specific implementations in the same way.
+type Definition struct {
+	Name        string
+	Description string
+	// supported GOOS/GOARCH pairs such as "linux/amd64"; nil means any
+	Platforms []string
+	// root is <TINYENV_ROOT>/<Name>, and opts may be nil
+	New func(root string, opts *Options) Specific
+}
+
+// the platforms that OSArch knows
+var osArchPlatforms = []string{"darwin/amd64", "darwin/arm64", "linux/amd64", "linux/arm64"}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Definition{}
+)
+
+// All is the sorted names of the registered languages that support this platform.
+// Register keeps it up to date.
+var All []string
+
+// Register makes a language available by def.Name. It panics if the name is already registered.
+func Register(def *Definition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if def.Name == "" || def.New == nil {
+		panic("language: Register needs Name and New")
+	}
+	if _, ok := registry[def.Name]; ok {
+		panic("language: Register called twice for " + def.Name)
+	}
+	registry[def.Name] = def
+	if def.Supported() {
+		i, _ := slices.BinarySearch(All, def.Name)
+		All = slices.Insert(All, i, def.Name)
+	}
+}
+
+// Lookup returns the registered Definition of name
+func Lookup(name string) (*Definition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	def, ok := registry[name]
+	return def, ok
+}
+
+// Definitions returns the registered Definitions sorted by name
+func Definitions() []*Definition {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]*Definition, 0, len(registry))
+	for _, name := range slices.Sorted(maps.Keys(registry)) {
+		out = append(out, registry[name])
+	}
+	return out
+}
+
+func (d *Definition) Supported() bool {
+	return d.Platforms == nil || slices.Contains(d.Platforms, runtime.GOOS+"/"+runtime.GOARCH)
+}
@@ -0,0 +1,29 @@
+package language
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"go", "java", "node", "perl", "python", "raku", "ruby", "solr"} {
+		def, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("%s is not registered", name)
+		}
+		if def.Description == "" {
+			t.Errorf("%s has no description", name)
+		}
+	}
+	names := All
+	if !slices.IsSorted(names) {
+		t.Errorf("All is not sorted: %v", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register did not panic for a duplicate name")
+		}
+	}()
+	Register(&Definition{Name: "go", New: func(root string, opts *Options) Specific { return &Go{Root: root} }})
+}
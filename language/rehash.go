@@ -0,0 +1,86 @@
+package language
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/skaji/tinyenv/config"
+)
+
+// RehashAll rehashes langs at once.
+//
+// All shims are written to a staging directory first, so that an error leaves <root>/bin untouched.
+// Then each shim is moved into <root>/bin with rename(2), which replaces an old shim atomically,
+// and finally shims that are no longer provided are removed.
+// Files in <root>/bin that are not tinyenv shims, such as tinyenv itself, are left alone.
+func RehashAll(root string, cfg *config.Config, langs []*Language) error {
+	unlock, err := rootLock(root)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	binDir := filepath.Join(root, "bin")
+	owners := map[string]string{}
+	shims := map[string]string{}
+	for _, l := range langs {
+		s, err := l.shims()
+		if err != nil {
+			return fmt.Errorf("%s rehash error: %w", l.Name, err)
+		}
+		for _, name := range slices.Sorted(maps.Keys(s)) {
+			if owner, ok := owners[name]; ok && !preferNew(cfg, name, owner, l.Name) {
+				continue
+			}
+			owners[name] = l.Name
+			shims[name] = s[name]
+		}
+	}
+
+	entries, err := os.ReadDir(binDir)
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for _, e := range entries {
+		path := filepath.Join(binDir, e.Name())
+		if e.IsDir() {
+			delete(shims, e.Name())
+			continue
+		}
+		if _, err := ReadShim(path); err != nil {
+			if _, ok := shims[e.Name()]; ok {
+				fmt.Fprintf(os.Stderr, "warning: %s is not a tinyenv shim, so %s does not overwrite it\n", path, owners[e.Name()])
+				delete(shims, e.Name())
+			}
+			continue
+		}
+		if _, ok := shims[e.Name()]; !ok {
+			stale = append(stale, path)
+		}
+	}
+
+	staging, err := os.MkdirTemp(root, ".bin-staging-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	for name, content := range shims {
+		if err := os.WriteFile(filepath.Join(staging, name), []byte(content), 0o755); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(shims)) {
+		if err := os.Rename(filepath.Join(staging, name), filepath.Join(binDir, name)); err != nil {
+			return err
+		}
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
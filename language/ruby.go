@@ -17,10 +17,21 @@ type Ruby struct {
 	Root string
 }
 
+func init() {
+	Register(&Definition{
+		Name:        "ruby",
+		Description: "portable-ruby from Homebrew",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Ruby{base: newBase(opts), Root: root}
+		},
+	})
+}
+
 const rubyAPIURL = "https://formulae.brew.sh/api/formula/portable-ruby.json"
 
 func (r *Ruby) list(ctx context.Context) (string, string, error) {
-	body, err := HTTPGet(ctx, rubyAPIURL)
+	body, err := r.get(ctx, r.url(rubyAPIURL))
 	if err != nil {
 		return "", "", err
 	}
@@ -103,14 +114,15 @@ func (r *Ruby) Install(ctx context.Context, version string) (string, error) {
 		return "", err
 	}
 
-	fmt.Println("---> Downloading " + url)
+	url = r.url(url)
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
 	modifier := func(req *http.Request) {
 		req.Header.Add("Authorization", "Bearer QQ==")
 	}
-	if err := HTTPMirror(ctx, url, cacheFile, modifier); err != nil {
+	if err := r.mirror(ctx, url, cacheFile, modifier); err != nil {
 		return "", err
 	}
-	fmt.Println("---> Extracting " + cacheFile)
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
 	if err := r.Untar(cacheFile, targetDir); err != nil {
 		return "", err
 	}
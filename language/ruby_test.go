@@ -0,0 +1,27 @@
+package language
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRuby(t *testing.T) {
+	server := fakeServer(t, map[string]string{
+		"/api/formula/portable-ruby.json": `{
+			"versions": {"stable": "3.3.6"},
+			"bottle": {"stable": {"files": {
+				"arm64_sonoma": {"url": "https://ghcr.io/v2/homebrew/portable-ruby/portable-ruby/blobs/sha256:1"},
+				"catalina": {"url": "https://ghcr.io/v2/homebrew/portable-ruby/portable-ruby/blobs/sha256:1"},
+				"x86_64_linux": {"url": "https://ghcr.io/v2/homebrew/portable-ruby/portable-ruby/blobs/sha256:1"},
+				"arm64_linux": {"url": "https://ghcr.io/v2/homebrew/portable-ruby/portable-ruby/blobs/sha256:1"}
+			}}}
+		}`,
+		"/ghcr/v2/homebrew/portable-ruby/portable-ruby/blobs/sha256:1": fakeTarball(t, "portable-ruby/3.3.6/bin/ruby", "portable-ruby/3.3.6/bin/gem"),
+	})
+	root := t.TempDir()
+	r := &Ruby{
+		base: newBase(fakeOptions(server, map[string]string{"https://formulae.brew.sh": "", "https://ghcr.io": "/ghcr"})),
+		Root: root,
+	}
+	testSpecific(t, r, root, []string{"homebrew-portable-3.3.6"}, "homebrew-portable-3.3.6", "latest", "homebrew-portable-3.3.6", filepath.Join("bin", "ruby"))
+}
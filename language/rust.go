@@ -0,0 +1,240 @@
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Rust struct {
+	*base
+	Root string
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "rust",
+		Description: "Rust toolchains from static.rust-lang.org",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Rust{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+var rustOSArch = &OSArch{
+	Linux:  "unknown-linux-gnu",
+	Darwin: "apple-darwin",
+	AMD64:  "x86_64",
+	ARM64:  "aarch64",
+}
+
+const rustURL = "https://github.com/rust-lang/rust"
+
+// channel
+const rustManifestURL = "https://static.rust-lang.org/dist/channel-rust-%s.toml"
+
+// date, channel
+const rustDatedManifestURL = "https://static.rust-lang.org/dist/%s/channel-rust-%s.toml"
+
+// GitHub shows 10 releases per page
+const rustMaxPages = 50
+
+func (r *Rust) List(ctx context.Context, all bool) ([]string, error) {
+	g := r.github()
+	var (
+		tags []string
+		err  error
+	)
+	if all {
+		tags, err = g.AllTags(ctx, rustURL, rustMaxPages)
+	} else {
+		tags, err = g.Tags(ctx, rustURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stable := regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+	var out []string
+	for _, tag := range tags {
+		if stable.MatchString(tag) {
+			out = append(out, tag)
+		}
+	}
+	if !all && len(out) > 10 {
+		out = out[:10]
+	}
+	return out, nil
+}
+
+func (r *Rust) Latest(ctx context.Context) (string, error) {
+	m, err := r.manifest(ctx, fmt.Sprintf(rustManifestURL, "stable"))
+	if err != nil {
+		return "", err
+	}
+	return m.version, nil
+}
+
+type rustManifest struct {
+	version string
+	url     string
+	hash    string
+}
+
+// manifest fetches a channel manifest, and finds the combined rust tarball for this platform.
+//
+// It is not a toml parser; it only understands
+//
+//	date = "2024-11-28"
+//	[pkg.rust]
+//	version = "1.83.0 (90b35a623 2024-11-26)"
+//	[pkg.rust.target.x86_64-unknown-linux-gnu]
+//	xz_url = "https://static.rust-lang.org/dist/2024-11-28/rust-1.83.0-x86_64-unknown-linux-gnu.tar.xz"
+//	xz_hash = "..."
+func (r *Rust) manifest(ctx context.Context, url string) (*rustManifest, error) {
+	b, err := r.get(ctx, r.url(url))
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("pkg.rust.target.%s-%s", rustOSArch.Arch(), rustOSArch.OS())
+	var (
+		section   string
+		date      string
+		version   string
+		available bool
+		m         = &rustManifest{}
+	)
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		key, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Unquote(value); err == nil {
+			value = v
+		}
+		switch {
+		case section == "" && key == "date":
+			date = value
+		case section == "pkg.rust" && key == "version":
+			version = value
+		case section == target && key == "available":
+			available = value == "true"
+		case section == target && key == "xz_url":
+			m.url = value
+		case section == target && key == "xz_hash":
+			m.hash = value
+		}
+	}
+	if !available || m.url == "" || m.hash == "" {
+		return nil, errors.New("no rust tarball for " + target + " in " + url)
+	}
+	// "1.83.0 (90b35a623 2024-11-26)", "1.85.0-nightly (d4025ee45 2024-12-12)"
+	version, _, _ = strings.Cut(version, " ")
+	if _, channel, ok := strings.Cut(version, "-"); ok {
+		channel, _, _ = strings.Cut(channel, ".")
+		version = channel + "-" + date
+	}
+	if version == "" {
+		return nil, errors.New("no rust version in " + url)
+	}
+	m.version = version
+	return m, nil
+}
+
+// version is one of
+//
+//	latest, stable, beta, nightly
+//	1.83.0
+//	stable-2024-11-28, beta-2024-11-28, nightly-2024-11-28
+func (r *Rust) manifestURL(version string) string {
+	switch version {
+	case "latest":
+		return fmt.Sprintf(rustManifestURL, "stable")
+	case "stable", "beta", "nightly":
+		return fmt.Sprintf(rustManifestURL, version)
+	}
+	if m := regexp.MustCompile(`^(stable|beta|nightly)-(\d{4}-\d{2}-\d{2})$`).FindStringSubmatch(version); m != nil {
+		return fmt.Sprintf(rustDatedManifestURL, m[2], m[1])
+	}
+	return fmt.Sprintf(rustManifestURL, version)
+}
+
+func (r *Rust) Install(ctx context.Context, version string) (string, error) {
+	m, err := r.manifest(ctx, r.manifestURL(version))
+	if err != nil {
+		return "", err
+	}
+	version = m.version
+
+	targetDir := filepath.Join(r.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	url := r.url(m.url)
+	cacheFile := filepath.Join(r.Root, "cache", version+".tar.xz")
+	if err := os.MkdirAll(filepath.Join(r.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := r.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	if err := VerifySHA256(cacheFile, m.hash); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := r.untar(cacheFile, targetDir, Output(ctx)); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// The combined tarball contains components (rustc, cargo, rust-std, ...),
+// so let its install.sh lay them out in targetDir
+func (r *Rust) Untar(cacheFile string, targetDir string) error {
+	return r.untar(cacheFile, targetDir, os.Stdout)
+}
+
+// untar prints the messages of install.sh to out
+func (r *Rust) untar(cacheFile string, targetDir string, out io.Writer) error {
+	if ExistsFS(targetDir) {
+		return errors.New("already exists " + targetDir)
+	}
+	tempDir, err := os.MkdirTemp(filepath.Dir(cacheFile), "rust-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	tempTargetDir := filepath.Join(tempDir, "rust")
+	if err := Untar(cacheFile, tempTargetDir); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "---> Installing "+targetDir)
+	cmd := exec.Command(
+		"/bin/sh",
+		filepath.Join(tempTargetDir, "install.sh"),
+		"--prefix="+targetDir,
+		"--disable-ldconfig",
+	)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		os.RemoveAll(targetDir)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package language
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestRustManifest(t *testing.T) {
+	if runtime.GOOS != "linux" || runtime.GOARCH != "amd64" {
+		t.Skip("the fixture only has x86_64-unknown-linux-gnu")
+	}
+	manifests := map[string]string{
+		"/stable.toml": `manifest-version = "2"
+date = "2024-11-28"
+[pkg.rust]
+version = "1.83.0 (90b35a623 2024-11-26)"
+[pkg.rust.target.x86_64-unknown-linux-gnu]
+available = true
+url = "https://example.com/rust-1.83.0-x86_64-unknown-linux-gnu.tar.gz"
+hash = "gz"
+xz_url = "https://example.com/rust-1.83.0-x86_64-unknown-linux-gnu.tar.xz"
+xz_hash = "xz"
+[[pkg.rust.target.x86_64-unknown-linux-gnu.components]]
+pkg = "rustc"
+target = "x86_64-unknown-linux-gnu"
+`,
+		"/nightly.toml": `date = "2024-12-13"
+[pkg.rust]
+version = "1.85.0-nightly (d4025ee45 2024-12-12)"
+[pkg.rust.target.x86_64-unknown-linux-gnu]
+available = true
+xz_url = "https://example.com/rust-nightly-x86_64-unknown-linux-gnu.tar.xz"
+xz_hash = "xz"
+`,
+		"/unavailable.toml": `date = "2024-12-13"
+[pkg.rust]
+version = "1.85.0-nightly (d4025ee45 2024-12-12)"
+[pkg.rust.target.x86_64-unknown-linux-gnu]
+available = false
+`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(manifests[req.URL.Path]))
+	}))
+	defer server.Close()
+
+	r := &Rust{}
+	m, err := r.manifest(context.Background(), server.URL+"/stable.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.version != "1.83.0" || m.url != "https://example.com/rust-1.83.0-x86_64-unknown-linux-gnu.tar.xz" || m.hash != "xz" {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+	m, err = r.manifest(context.Background(), server.URL+"/nightly.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.version != "nightly-2024-12-13" {
+		t.Errorf("unexpected version %s", m.version)
+	}
+	if _, err := r.manifest(context.Background(), server.URL+"/unavailable.toml"); err == nil {
+		t.Error("expected an error for an unavailable target")
+	}
+}
@@ -0,0 +1,116 @@
+package language
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// stableVersion is a version such as "1.22.5", "v22.12.0", "temurin-17.0.13+11" or "3.12.8+20241206".
+// Pre-releases such as "1.24rc1" or "0.14.0-dev.2577" are not stable versions.
+type stableVersion struct {
+	prefix string
+	nums   []int
+	build  int
+}
+
+var stableVersionRegexp = regexp.MustCompile(`^(\D*)(\d+(?:\.\d+)*)(?:\+(\d+))?$`)
+
+func parseStableVersion(version string) (*stableVersion, bool) {
+	m := stableVersionRegexp.FindStringSubmatch(version)
+	if m == nil {
+		return nil, false
+	}
+	v := &stableVersion{prefix: m[1], build: -1}
+	for _, str := range strings.Split(m[2], ".") {
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, false
+		}
+		v.nums = append(v.nums, n)
+	}
+	if m[3] != "" {
+		v.build, _ = strconv.Atoi(m[3])
+	}
+	return v, true
+}
+
+func (v *stableVersion) series(major bool) string {
+	n := 2
+	if major {
+		n = 1
+	}
+	var parts []string
+	for _, num := range v.nums[:min(n, len(v.nums))] {
+		parts = append(parts, strconv.Itoa(num))
+	}
+	return v.prefix + strings.Join(parts, ".")
+}
+
+func (v *stableVersion) compare(other *stableVersion) int {
+	if c := slices.Compare(v.nums, other.nums); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.build, other.build)
+}
+
+// Series returns the minor series ("1.22" for "1.22.5"), or the major series ("temurin-17" for "temurin-17.0.13+11") of version.
+// It returns false if version is not a stable version.
+func Series(version string, major bool) (string, bool) {
+	v, ok := parseStableVersion(version)
+	if !ok {
+		return "", false
+	}
+	return v.series(major), true
+}
+
+// NewestInSeries returns the newest stable version of candidates in the series of version.
+// It returns version itself if there is nothing newer.
+func NewestInSeries(version string, candidates []string, major bool) string {
+	current, ok := parseStableVersion(version)
+	if !ok {
+		return version
+	}
+	series := current.series(major)
+	newest, newestVersion := current, version
+	for _, candidate := range candidates {
+		v, ok := parseStableVersion(candidate)
+		if !ok || v.series(major) != series {
+			continue
+		}
+		if v.compare(newest) > 0 {
+			newest, newestVersion = v, candidate
+		}
+	}
+	return newestVersion
+}
+
+// ResolveVersion returns the newest version of candidates that starts with the numbers of version,
+// such as "v22.12.0" for "22", or "temurin-21.0.5+11" for "21".
+// It returns version itself if version is one of candidates, or nothing matches.
+func ResolveVersion(version string, candidates []string) string {
+	if slices.Contains(candidates, version) {
+		return version
+	}
+	want, ok := parseStableVersion(version)
+	if !ok || want.build >= 0 {
+		return version
+	}
+	var newest *stableVersion
+	newestVersion := version
+	for _, candidate := range candidates {
+		v, ok := parseStableVersion(candidate)
+		if !ok || len(v.nums) < len(want.nums) || !slices.Equal(v.nums[:len(want.nums)], want.nums) {
+			continue
+		}
+		if want.prefix != "" && v.prefix != want.prefix {
+			continue
+		}
+		if newest == nil || v.compare(newest) > 0 {
+			newest, newestVersion = v, candidate
+		}
+	}
+	return newestVersion
+}
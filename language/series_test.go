@@ -0,0 +1,46 @@
+package language
+
+import "testing"
+
+func TestNewestInSeries(t *testing.T) {
+	tests := []struct {
+		version    string
+		candidates []string
+		major      bool
+		want       string
+	}{
+		{"1.22.5", []string{"1.24rc1", "1.23.4", "1.22.10", "1.22.9", "1.21.13"}, false, "1.22.10"},
+		{"1.22.5", []string{"1.24rc1", "1.23.4", "1.22.10"}, true, "1.23.4"},
+		{"v22.1.0", []string{"v23.4.0", "v22.12.0", "v20.18.1"}, true, "v22.12.0"},
+		{"temurin-17.0.9+9", []string{"temurin-21.0.5+11", "temurin-17.0.13+11", "temurin-17.0.13+7"}, true, "temurin-17.0.13+11"},
+		{"3.12.5+20240814", []string{"3.13.1+20241206", "3.12.8+20241206"}, false, "3.12.8+20241206"},
+		{"0.13.0", []string{"0.14.0-dev.2577+271452d22", "0.13.0"}, true, "0.13.0"},
+		{"nightly-2024-12-13", []string{"1.83.0"}, false, "nightly-2024-12-13"},
+	}
+	for _, test := range tests {
+		if got := NewestInSeries(test.version, test.candidates, test.major); got != test.want {
+			t.Errorf("NewestInSeries(%q, %v, %v) = %q, want %q", test.version, test.candidates, test.major, got, test.want)
+		}
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		candidates []string
+		want       string
+	}{
+		{"22", []string{"v23.4.0", "v22.12.0", "v22.9.0", "v20.18.1"}, "v22.12.0"},
+		{"v22.9", []string{"v23.4.0", "v22.12.0", "v22.9.0"}, "v22.9.0"},
+		{"3.12", []string{"3.13.1+20241206", "3.12.8+20241206", "3.12.8+20241002"}, "3.12.8+20241206"},
+		{"21", []string{"temurin-23.0.1+11", "temurin-21.0.5+11"}, "temurin-21.0.5+11"},
+		{"1.2", []string{"1.23.4", "1.22.10"}, "1.2"},
+		{"1.23.4", []string{"1.23.4"}, "1.23.4"},
+		{"latest", []string{"1.23.4"}, "latest"},
+	}
+	for _, test := range tests {
+		if got := ResolveVersion(test.version, test.candidates); got != test.want {
+			t.Errorf("ResolveVersion(%q, %v) = %q, want %q", test.version, test.candidates, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package language
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Shim is a shell script written by Rehash
+type Shim struct {
+	Language string
+	// the executable that the shim execs
+	Target string
+}
+
+var shimExec = regexp.MustCompile(`(?m)^exec "(.+)" "\$@"$`)
+
+func ReadShim(path string) (*Shim, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.SplitN(string(b), "\n", 3)
+	if len(lines) < 3 || lines[0] != "#!/bin/sh" || !strings.HasPrefix(lines[1], "# ") {
+		return nil, errors.New(path + " is not a tinyenv shim")
+	}
+	m := shimExec.FindStringSubmatch(lines[2])
+	if m == nil {
+		return nil, errors.New(path + " is not a tinyenv shim")
+	}
+	return &Shim{Language: strings.TrimPrefix(lines[1], "# "), Target: m[1]}, nil
+}
@@ -2,88 +2,54 @@ package language
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"slices"
+	"strconv"
+	"strings"
 )
 
+// Solr moved out of Lucene at 9.0, so older releases are under lucene/solr/
+var solrDist = &ApacheDist{
+	Paths: []string{"solr/solr/", "lucene/solr/"},
+	// <a href="9.0.0/">9.0.0/</a>
+	Version: regexp.MustCompile(`<a href="([\d.]+)/">`),
+	Asset: func(version string) string {
+		major, _, _ := strings.Cut(version, ".")
+		if m, err := strconv.Atoi(major); err == nil && m < 9 {
+			return fmt.Sprintf("lucene/solr/%s/solr-%s.tgz", version, version)
+		}
+		return fmt.Sprintf("solr/solr/%s/solr-%s.tgz", version, version)
+	},
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "solr",
+		Description: "Apache Solr from archive.apache.org",
+		New: func(root string, opts *Options) Specific {
+			return &Solr{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+// Solr installs Apache Solr. It is an Apache with the Solr dist.
 type Solr struct {
 	*base
 	Root string
 }
 
-const solrVersionsURL = "https://archive.apache.org/dist/solr/solr/"
-
-// version, version
-const solrAssetURL = "https://archive.apache.org/dist/solr/solr/%s/solr-%s.tgz"
-
-// version, version
-const solrFastAssetURL = "https://downloads.apache.org/solr/solr/%s/solr-%s.tgz"
+func (s *Solr) apache() *Apache {
+	return &Apache{base: s.base, Root: s.Root, Dist: solrDist}
+}
 
 func (s *Solr) List(ctx context.Context, all bool) ([]string, error) {
-	b, err := HTTPGet(ctx, solrVersionsURL)
-	if err != nil {
-		return nil, err
-	}
-	// <a href="9.0.0/">9.0.0/</a>
-	matches := regexp.MustCompile(`<a href="([\d.]+)/">`).FindAllStringSubmatch(string(b), -1)
-	if len(matches) == 0 {
-		return nil, errors.New("no versions for solr")
-	}
-	var out []string
-	for _, m := range matches {
-		out = append(out, m[1])
-	}
-	slices.Reverse(out)
-	if !all && len(out) > 10 {
-		return out[:10], nil
-	}
-	return out, nil
+	return s.apache().List(ctx, all)
 }
 
 func (s *Solr) Latest(ctx context.Context) (string, error) {
-	out, err := s.List(ctx, true)
-	if err != nil {
-		return "", err
-	}
-	if len(out) == 0 {
-		return "", errors.New("not found")
-	}
-	return out[0], nil
+	return s.apache().Latest(ctx)
 }
 
 func (s *Solr) Install(ctx context.Context, version string) (string, error) {
-	if version == "latest" {
-		latest, err := s.Latest(ctx)
-		if err != nil {
-			return "", err
-		}
-		version = latest
-	}
-	targetDir := filepath.Join(s.Root, "versions", version)
-	if ExistsFS(targetDir) {
-		return "", errors.New("already exists " + targetDir)
-	}
-
-	url := fmt.Sprintf(solrFastAssetURL, version, version)
-	if err := HTTPHead(ctx, url); err != nil {
-		url = fmt.Sprintf(solrAssetURL, version, version)
-	}
-	cacheFile := filepath.Join(s.Root, "cache", version+".tar.gz")
-	if err := os.MkdirAll(filepath.Join(s.Root, "cache"), 0o755); err != nil {
-		return "", err
-	}
-
-	fmt.Println("---> Downloading " + url)
-	if err := HTTPMirror(ctx, url, cacheFile, nil); err != nil {
-		return "", err
-	}
-	fmt.Println("---> Extracting " + cacheFile)
-	if err := s.Untar(cacheFile, targetDir); err != nil {
-		return "", err
-	}
-	return version, nil
+	return s.apache().Install(ctx, version)
 }
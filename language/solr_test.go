@@ -0,0 +1,26 @@
+package language
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+)
+
+func TestSolr(t *testing.T) {
+	tarball := fakeTarball(t, "solr-8.11.4/bin/solr", "solr-8.11.4/bin/post")
+	sum := sha512.Sum512([]byte(tarball))
+	server := fakeServer(t, map[string]string{
+		"/archive/solr/solr/":   `<a href="9.7.0/">9.7.0/</a> <a href="9.10.0/">9.10.0/</a>`,
+		"/archive/lucene/solr/": `<a href="8.11.4/">8.11.4/</a> <a href="8.9.0/">8.9.0/</a>`,
+		// 8.11.4 is not in downloads.apache.org any more
+		"/archive/lucene/solr/8.11.4/solr-8.11.4.tgz":        tarball,
+		"/archive/lucene/solr/8.11.4/solr-8.11.4.tgz.sha512": hex.EncodeToString(sum[:]) + "  solr-8.11.4.tgz\n",
+	})
+	root := t.TempDir()
+	s := &Solr{
+		base: newBase(fakeOptions(server, map[string]string{"https://archive.apache.org/dist/": "/archive/", "https://downloads.apache.org/": "/downloads/"})),
+		Root: root,
+	}
+	testSpecific(t, s, root, []string{"9.10.0", "9.7.0", "8.11.4", "8.9.0"}, "9.10.0", "8.11.4", "8.11.4", filepath.Join("bin", "solr"))
+}
@@ -0,0 +1,27 @@
+package language
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var tomcatDist = &ApacheDist{
+	Paths: []string{"tomcat/tomcat-11/", "tomcat/tomcat-10/", "tomcat/tomcat-9/"},
+	// <a href="v10.1.34/">v10.1.34/</a>
+	Version: regexp.MustCompile(`<a href="v([\d.]+)/">`),
+	Asset: func(version string) string {
+		major, _, _ := strings.Cut(version, ".")
+		return fmt.Sprintf("tomcat/tomcat-%s/v%s/bin/apache-tomcat-%s.tar.gz", major, version, version)
+	},
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "tomcat",
+		Description: "Apache Tomcat from archive.apache.org",
+		New: func(root string, opts *Options) Specific {
+			return &Apache{base: newBase(opts), Root: root, Dist: tomcatDist}
+		},
+	})
+}
@@ -1,14 +1,21 @@
 package language
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/schollz/progressbar/v3"
 )
@@ -77,9 +84,160 @@ func UntarStrip(tarball string, targetDir string, strip int) error {
 	return cmd.Run()
 }
 
+func Unzip(zipfile string, targetDir string) error {
+	return UnzipStrip(zipfile, targetDir, 1)
+}
+
+// UnzipStrip extracts zipfile into targetDir like `tar --strip-components`,
+// keeping the permission bits and symlinks recorded in the archive.
+func UnzipStrip(zipfile string, targetDir string, strip int) error {
+	if ExistsFS(targetDir) {
+		return errors.New("already exists " + targetDir)
+	}
+	r, err := zip.OpenReader(zipfile)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+		return err
+	}
+	for _, f := range r.File {
+		parts := strings.Split(strings.Trim(f.Name, "/"), "/")
+		if len(parts) <= strip {
+			continue
+		}
+		name := filepath.Join(parts[strip:]...)
+		if !filepath.IsLocal(name) {
+			return errors.New("invalid file name in " + zipfile + ": " + f.Name)
+		}
+		// a symlink extracted earlier would let this entry be written outside targetDir
+		if crossesSymlink(targetDir, name) {
+			return errors.New("invalid file name in " + zipfile + ": " + f.Name + " goes through a symlink")
+		}
+		path := filepath.Join(targetDir, name)
+		mode := f.Mode()
+		if mode.IsDir() {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if mode&os.ModeSymlink != 0 {
+			if err := unzipSymlink(f, name, path); err != nil {
+				return fmt.Errorf("%s: %w", zipfile, err)
+			}
+			continue
+		}
+		if err := unzipFile(f, path, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unzipSymlink creates the symlink name at path.
+// Its target must stay in targetDir, or later entries could be written outside targetDir through it.
+func unzipSymlink(f *zip.File, name string, path string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	link, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return err
+	}
+	if filepath.IsAbs(string(link)) || !filepath.IsLocal(filepath.Join(filepath.Dir(name), string(link))) {
+		return errors.New("invalid symlink " + f.Name + " -> " + string(link))
+	}
+	return os.Symlink(string(link), path)
+}
+
+// crossesSymlink reports whether dir/name, or any directory between dir and it, is a symlink
+func crossesSymlink(dir string, name string) bool {
+	path := dir
+	for _, part := range strings.Split(name, string(filepath.Separator)) {
+		path = filepath.Join(path, part)
+		info, err := os.Lstat(path)
+		if err != nil {
+			return false
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func unzipFile(f *zip.File, path string, mode os.FileMode) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm()|0o200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// VerifySHA256 removes file if its sha256 digest is not the expected one,
+// so that the next install downloads it again.
+func VerifySHA256(file string, expected string) error {
+	return verifyDigest(file, "sha256", sha256.New(), expected)
+}
+
+// VerifySHA512 is the sha512 version of VerifySHA256.
+func VerifySHA512(file string, expected string) error {
+	return verifyDigest(file, "sha512", sha512.New(), expected)
+}
+
+func verifyDigest(file string, name string, h hash.Hash, expected string) error {
+	got, err := digestFile(file, h)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(got, expected) {
+		os.Remove(file)
+		return fmt.Errorf("%s mismatch for %s: expected %s, got %s", name, file, expected, got)
+	}
+	return nil
+}
+
+// SHA256File returns the hex encoded sha256 digest of file
+func SHA256File(file string) (string, error) {
+	return digestFile(file, sha256.New())
+}
+
+func digestFile(file string, h hash.Hash) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(h, f)
+	f.Close()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func HTTPGet(ctx context.Context, url string) ([]byte, error) {
+	return httpGet(ctx, http.DefaultClient, url)
+}
+
+func httpGet(ctx context.Context, client *http.Client, url string) ([]byte, error) {
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +253,12 @@ func HTTPGet(ctx context.Context, url string) ([]byte, error) {
 }
 
 func HTTPHead(ctx context.Context, url string) error {
+	return httpHead(ctx, http.DefaultClient, url)
+}
+
+func httpHead(ctx context.Context, client *http.Client, url string) error {
 	req, _ := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -109,6 +271,20 @@ func HTTPHead(ctx context.Context, url string) error {
 }
 
 func HTTPMirror(ctx context.Context, url string, targetFile string, modifier func(req *http.Request)) error {
+	return httpMirrorRecord(ctx, http.DefaultClient, url, targetFile, modifier)
+}
+
+func httpMirrorRecord(ctx context.Context, client *http.Client, url string, targetFile string, modifier func(req *http.Request)) error {
+	if err := httpMirror(ctx, client, url, targetFile, modifier); err != nil {
+		return err
+	}
+	if r := downloadRecorderFrom(ctx); r != nil {
+		return r.record(url, targetFile)
+	}
+	return nil
+}
+
+func httpMirror(ctx context.Context, client *http.Client, url string, targetFile string, modifier func(req *http.Request)) error {
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if info, err := os.Stat(targetFile); err == nil {
 		req.Header.Set("If-Modified-Since", info.ModTime().Format(http.TimeFormat))
@@ -116,7 +292,7 @@ func HTTPMirror(ctx context.Context, url string, targetFile string, modifier fun
 	if modifier != nil {
 		modifier(req)
 	}
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -136,7 +312,12 @@ func HTTPMirror(ctx context.Context, url string, targetFile string, modifier fun
 		return err
 	}
 
-	bar := progressbar.DefaultBytes(res.ContentLength, "")
+	var bar io.WriteCloser
+	if p, label := progressFrom(ctx); p != nil {
+		bar = p.Add(label, res.ContentLength)
+	} else {
+		bar = progressbar.DefaultBytes(res.ContentLength, "")
+	}
 	_, copyErr := io.Copy(io.MultiWriter(f, bar), res.Body)
 	bar.Close()
 	f.Close()
@@ -0,0 +1,122 @@
+package language
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnzipStrip(t *testing.T) {
+	dir := t.TempDir()
+	zipfile := filepath.Join(dir, "test.zip")
+	f, err := os.Create(zipfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	add := func(name string, mode os.FileMode, content string) {
+		h := &zip.FileHeader{Name: name}
+		h.SetMode(mode)
+		fw, err := w.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("top/", os.ModeDir|0o755, "")
+	add("top/exe", 0o755, "#!/bin/sh\n")
+	add("top/doc/README", 0o644, "readme")
+	add("top/link", os.ModeSymlink|0o777, "exe")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	targetDir := filepath.Join(dir, "target")
+	if err := UnzipStrip(zipfile, targetDir, 1); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(targetDir, "exe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o755 {
+		t.Errorf("exe mode = %v", info.Mode())
+	}
+	if b, err := os.ReadFile(filepath.Join(targetDir, "doc", "README")); err != nil || string(b) != "readme" {
+		t.Errorf("README = %q, %v", b, err)
+	}
+	if link, err := os.Readlink(filepath.Join(targetDir, "link")); err != nil || link != "exe" {
+		t.Errorf("link = %q, %v", link, err)
+	}
+	if err := UnzipStrip(zipfile, targetDir, 1); err == nil {
+		t.Error("expected an error for an existing target directory")
+	}
+}
+
+type zipEntry struct {
+	name    string
+	mode    os.FileMode
+	content string
+}
+
+func writeZip(t *testing.T, zipfile string, entries []zipEntry) {
+	t.Helper()
+	f, err := os.Create(zipfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name}
+		h.SetMode(e.mode)
+		fw, err := w.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnzipStripSymlinkEscape(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []zipEntry
+	}{
+		{"parent", []zipEntry{{"link", os.ModeSymlink | 0o777, "../outside"}}},
+		{"absolute", []zipEntry{{"link", os.ModeSymlink | 0o777, "/etc"}}},
+		{"nested parent", []zipEntry{{"link", os.ModeSymlink | 0o777, "sub/../../outside"}}},
+		{"chained", []zipEntry{
+			{"a", os.ModeSymlink | 0o777, "."},
+			{"a/b", os.ModeSymlink | 0o777, ".."},
+			{"b/evil", 0o644, "evil"},
+		}},
+		{"file through a symlink", []zipEntry{
+			{"c", os.ModeSymlink | 0o777, "."},
+			{"d", os.ModeSymlink | 0o777, "c/../evil"},
+			{"d", 0o644, "evil"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			zipfile := filepath.Join(dir, "test.zip")
+			writeZip(t, zipfile, tt.entries)
+			if err := UnzipStrip(zipfile, filepath.Join(dir, "target"), 0); err == nil {
+				t.Error("expected an error")
+			}
+			if ExistsFS(filepath.Join(dir, "evil")) {
+				t.Error("evil is written outside the target directory")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package language
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/mod/semver"
+)
+
+type Zig struct {
+	*base
+	Root string
+}
+
+func init() {
+	Register(&Definition{
+		Name:        "zig",
+		Description: "Zig from ziglang.org",
+		Platforms:   osArchPlatforms,
+		New: func(root string, opts *Options) Specific {
+			return &Zig{base: newBase(opts), Root: root}
+		},
+	})
+}
+
+var zigOSArch = &OSArch{
+	Linux:  "linux",
+	Darwin: "macos",
+	AMD64:  "x86_64",
+	ARM64:  "aarch64",
+}
+
+const zigVersionsURL = "https://ziglang.org/download/index.json"
+
+type zigAsset struct {
+	Version string
+	Tarball string `json:"tarball"`
+	Shasum  string `json:"shasum"`
+	Dev     bool
+}
+
+func (z *Zig) list(ctx context.Context) ([]*zigAsset, error) {
+	b, err := z.get(ctx, z.url(zigVersionsURL))
+	if err != nil {
+		return nil, err
+	}
+	var index map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, err
+	}
+	platform := zigOSArch.Arch() + "-" + zigOSArch.OS()
+	var out []*zigAsset
+	for key, release := range index {
+		raw, ok := release[platform]
+		if !ok {
+			continue
+		}
+		var asset *zigAsset
+		if err := json.Unmarshal(raw, &asset); err != nil {
+			return nil, err
+		}
+		asset.Version = key
+		if key == "master" {
+			if err := json.Unmarshal(release["version"], &asset.Version); err != nil {
+				return nil, err
+			}
+			asset.Dev = true
+		}
+		out = append(out, asset)
+	}
+	slices.SortFunc(out, func(a1, a2 *zigAsset) int {
+		return semver.Compare("v"+a2.Version, "v"+a1.Version)
+	})
+	return out, nil
+}
+
+func (z *Zig) List(ctx context.Context, all bool) ([]string, error) {
+	assets, err := z.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, asset := range assets {
+		if asset.Dev && !all {
+			continue
+		}
+		out = append(out, asset.Version)
+	}
+	if !all && len(out) > 10 {
+		out = out[:10]
+	}
+	return out, nil
+}
+
+func (z *Zig) Latest(ctx context.Context) (string, error) {
+	out, err := z.List(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", errors.New("not found")
+	}
+	return out[0], nil
+}
+
+func (z *Zig) Install(ctx context.Context, version string) (string, error) {
+	assets, err := z.list(ctx)
+	if err != nil {
+		return "", err
+	}
+	index := slices.IndexFunc(assets, func(a *zigAsset) bool {
+		switch version {
+		case "latest":
+			return !a.Dev
+		case "master":
+			return a.Dev
+		}
+		return a.Version == version
+	})
+	if index == -1 {
+		return "", errors.New("invalid version: " + version)
+	}
+	asset := assets[index]
+	version = asset.Version
+
+	targetDir := filepath.Join(z.Root, "versions", version)
+	if ExistsFS(targetDir) {
+		return "", errors.New("already exists " + targetDir)
+	}
+
+	url := z.url(asset.Tarball)
+	cacheFile := filepath.Join(z.Root, "cache", version+".tar.xz")
+	if err := os.MkdirAll(filepath.Join(z.Root, "cache"), 0o755); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintln(Output(ctx), "---> Downloading "+url)
+	if err := z.mirror(ctx, url, cacheFile, nil); err != nil {
+		return "", err
+	}
+	if err := VerifySHA256(cacheFile, asset.Shasum); err != nil {
+		return "", err
+	}
+	fmt.Fprintln(Output(ctx), "---> Extracting "+cacheFile)
+	if err := z.Untar(cacheFile, targetDir); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (z *Zig) BinDirs() []string {
+	return []string{"."}
+}
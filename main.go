@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -30,50 +32,45 @@ Commands:
   %s
 
 Examples:
+  ❯ eval "$(tinyenv init zsh --hook)"
   ❯ tinyenv versions
+  ❯ tinyenv which python3
   ❯ tinyenv python install -l
   ❯ tinyenv python install 3.9.19+20240814
   ❯ tinyenv python install latest
   ❯ tinyenv python global 3.12.5+20240814
-`
-
-var zshCompletions = `compctl -K _tinyenv tinyenv
-
-_tinyenv() {
-  local words completions
-  local lang cmd
-  read -cA words
-
-  if [[ ${#words} -eq 2 ]]; then
-    completions="$(tinyenv --completion1)"
-  elif [[ ${#words} -eq 3 ]]; then
-    completions="$(tinyenv --completion2)"
-  elif [[ ${#words} -eq 4 ]]; then
-    lang=$words[2]
-    cmd=$words[3]
-    if [[ $cmd = global ]]; then
-      completions="$(tinyenv $lang versions --bare)"
-    fi
-  fi
-  reply=("${(ps:\n:)completions}")
-}
+  ❯ tinyenv go upgrade --remove
+  ❯ tinyenv install go@1.23.1 node@22 python@3.12 java@21
 `
 
 func main() {
 	globalCommands := []string{
+		"doctor",
+		"export",
 		"files",
+		"import",
+		"init",
+		"install",
 		"latest",
+		"outdated",
 		"rehash",
 		"root",
+		"self-update",
+		"sync",
 		"version",
 		"versions",
+		"whence",
+		"which",
 	}
+	language.Reserved = globalCommands
 	languageCommands := []string{
 		"global",
 		"install",
 		"latest",
 		"rehash",
 		"reset",
+		"uninstall",
+		"upgrade",
 		"version",
 		"versions",
 	}
@@ -82,7 +79,13 @@ func main() {
 		switch os.Args[1] {
 		case "-h", "--help":
 			globalCommandStr := strings.Join(globalCommands, "\n  ")
-			languageStr := strings.Join(language.All, "\n  ")
+			var languageLines []string
+			for _, def := range language.Definitions() {
+				if def.Supported() {
+					languageLines = append(languageLines, fmt.Sprintf("%-8s %s", def.Name, def.Description))
+				}
+			}
+			languageStr := strings.Join(languageLines, "\n  ")
 			languageCommandStr := strings.Join(languageCommands, "\n  ")
 			fmt.Printf(helpMessage, globalCommandStr, languageStr, languageCommandStr)
 			os.Exit(1)
@@ -92,21 +95,27 @@ func main() {
 		case "zsh-completions":
 			fmt.Print(zshCompletions)
 			os.Exit(0)
-		case "--completion1":
-			for _, l := range language.All {
-				fmt.Println(l)
-			}
-			for _, c := range globalCommands {
-				fmt.Println(c)
-			}
+		case "bash-completions":
+			fmt.Print(bashCompletions)
 			os.Exit(0)
-		case "--completion2":
-			for _, c := range languageCommands {
-				fmt.Println(c)
-			}
+		case "fish-completions":
+			fmt.Print(fishCompletions)
 			os.Exit(0)
 		}
 	}
+	if len(os.Args) >= 2 && os.Args[1] == "--complete" {
+		for _, c := range complete(globalCommands, languageCommands, os.Args[2:]) {
+			fmt.Println(c)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) == 2 && os.Args[1] == "--check-pinned" {
+		if root, err := selectRoot(); err == nil {
+			cfg, _ := readConfig(root)
+			checkPinned(root, language.Names(root, cfg))
+		}
+		os.Exit(0)
+	}
 	if len(os.Args) < 3 &&
 		!(len(os.Args) == 2 && slices.Contains(globalCommands, os.Args[1])) {
 		fmt.Fprintln(os.Stderr, "invalid arguments")
@@ -123,30 +132,149 @@ func main() {
 		os.Exit(1)
 	}
 
-	var cfg *config.Config
-	if path := filepath.Join(root, "config.json"); language.ExistsFS(path) {
-		c, err := config.NewFromFile(path)
-		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+	if os.Args[1] == "doctor" {
+		if !doctor(root) {
 			os.Exit(1)
 		}
-		cfg = c
+		os.Exit(0)
+	}
+
+	cfg, err := readConfig(root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	languages := language.Names(root, cfg)
+	for _, err := range language.InvalidPlugins(root) {
+		fmt.Fprintln(os.Stderr, "---> skip plugin "+err.Error())
 	}
 
 	switch os.Args[1] {
 	case "root":
 		fmt.Println(root)
 		os.Exit(0)
+	case "init":
+		args := os.Args[2:]
+		hook := slices.Contains(args, "--hook")
+		args = slices.DeleteFunc(args, func(arg string) bool { return arg == "--hook" })
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "need shell argument (zsh, bash or fish)")
+			os.Exit(1)
+		}
+		script, err := shellInit(root, args[0], hook)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+		os.Exit(0)
+	case "export":
+		m, err := exportMachine(root, cfg, languages)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		b, _ := json.MarshalIndent(m, "", "  ")
+		b = append(b, '\n')
+		if len(os.Args) > 2 {
+			err = os.WriteFile(os.Args[2], b, 0o644)
+		} else {
+			_, err = os.Stdout.Write(b)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "import":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "need file argument")
+			os.Exit(1)
+		}
+		m, err := readMachine(os.Args[2])
+		if err == nil {
+			err = importMachine(context.Background(), root, m)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "self-update":
+		check := slices.Contains(os.Args[2:], "--check")
+		if err := selfUpdate(context.Background(), check); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "install":
+		args := os.Args[2:]
+		global := len(args) > 0 && (args[0] == "-g" || args[0] == "--global")
+		if global {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "need LANGUAGE@VERSION arguments")
+			os.Exit(1)
+		}
+		items, err := parseInstallItems(root, cfg, languages, args)
+		if err == nil {
+			err = installMany(context.Background(), items, global)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "sync":
+		m, err := loadManifest(os.Args[2:])
+		if err == nil {
+			err = syncManifest(context.Background(), root, cfg, languages, m)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "which", "whence":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "need command argument")
+			os.Exit(1)
+		}
+		if os.Args[1] == "which" {
+			err = which(root, os.Args[2])
+		} else {
+			err = whence(root, cfg, languages, os.Args[2])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	case "version":
-		for _, l := range language.All {
+		_, jsonOutput := jsonFlag(os.Args[2:])
+		results := []*versionResult{}
+		for _, l := range languages {
 			lang := &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
 			if version, err := lang.Version(); err == nil {
-				fmt.Printf("%s %s\n", l, version)
+				results = append(results, newVersionResult(lang, version, version))
 			}
 		}
+		if jsonOutput {
+			if err := printJSON(results); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+		for _, res := range results {
+			fmt.Printf("%s %s\n", res.Language, res.Version)
+		}
 		os.Exit(0)
 	case "versions":
-		for _, l := range language.All {
+		_, jsonOutput := jsonFlag(os.Args[2:])
+		results := []*versionResult{}
+		for _, l := range languages {
 			lang := &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
 			versions, err := lang.Versions()
 			if err != nil {
@@ -154,66 +282,88 @@ func main() {
 			}
 			version, _ := lang.Version()
 			for _, v := range versions {
-				mark := "  "
-				if v == version {
-					mark = "* "
-				}
-				fmt.Printf("%s%s %s\n", mark, l, v)
+				results = append(results, newVersionResult(lang, v, version))
 			}
 		}
-		os.Exit(0)
-	case "rehash":
-		for _, l := range language.All {
-			lang := &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
-			if err := lang.Rehash(); err != nil {
-				fmt.Fprintf(os.Stderr, "%s rehash error: %v", l, err)
+		if jsonOutput {
+			if err := printJSON(results); err != nil {
+				fmt.Fprintln(os.Stderr, err)
 				os.Exit(1)
 			}
+			os.Exit(0)
+		}
+		for _, res := range results {
+			mark := "  "
+			if res.Current {
+				mark = "* "
+			}
+			fmt.Printf("%s%s %s\n", mark, res.Language, res.Version)
 		}
 		os.Exit(0)
-	case "latest":
-		type result struct {
-			Language string `json:"language"`
-			Latest   string `json:"latest"`
-			Have     bool   `json:"have"`
+	case "rehash":
+		var langs []*language.Language
+		for _, l := range languages {
+			langs = append(langs, &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg})
 		}
-		results := make([]*result, len(language.All))
+		if err := language.RehashAll(root, cfg, langs); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "latest":
+		_, jsonOutput := jsonFlag(os.Args[2:])
+		results := make([]*versionResult, len(languages))
 		var wg sync.WaitGroup
-		wg.Add(len(language.All))
-		for i, l := range language.All {
+		wg.Add(len(languages))
+		for i, l := range languages {
 			go func() {
 				defer wg.Done()
 				lang := &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
+				current, _ := lang.Version()
 				latest, err := lang.Latest(context.Background())
+				results[i] = newVersionResult(lang, latest, current)
 				if err != nil {
-					results[i] = &result{
-						Language: l,
-						Latest:   "error: " + err.Error(),
-						Have:     false,
-					}
-					return
-				}
-				locals, _ := lang.Versions()
-				have := slices.Contains(locals, latest)
-				results[i] = &result{
-					Language: l,
-					Latest:   latest,
-					Have:     have,
+					results[i].Error = err.Error()
 				}
 			}()
 		}
 		wg.Wait()
+		if jsonOutput {
+			if err := printJSON(results); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
 		format := "%-5v  %-8s  %s\n"
 		fmt.Printf(format, "have?", "language", "latest")
 		fmt.Printf(format, "-----", "--------", "------")
 		for _, res := range results {
-			fmt.Printf(format, res.Have, res.Language, res.Latest)
+			latest := res.Version
+			if res.Error != "" {
+				latest = "error: " + res.Error
+			}
+			fmt.Printf(format, res.Installed, res.Language, latest)
+		}
+		os.Exit(0)
+	case "outdated":
+		_, jsonOutput := jsonFlag(os.Args[2:])
+		major := slices.Contains(os.Args[2:], "--major")
+		drift, err := outdated(context.Background(), root, cfg, languages, major, jsonOutput)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if drift {
+			os.Exit(1)
 		}
 		os.Exit(0)
 	case "files":
+		_, jsonOutput := jsonFlag(os.Args[2:])
+		results := []*fileResult{}
 		if entries, err := os.ReadDir(filepath.Join(root, "bin")); err == nil {
 			for _, e := range entries {
-				fmt.Println(filepath.Join(root, "bin", e.Name()))
+				results = append(results, &fileResult{Type: "bin", Path: filepath.Join(root, "bin", e.Name())})
 			}
 		}
 		entries, err := os.ReadDir(root)
@@ -229,27 +379,35 @@ func main() {
 				continue
 			}
 			if v := filepath.Join(root, entry.Name(), "version"); language.ExistsFS(v) {
-				fmt.Println(v)
+				results = append(results, &fileResult{Language: entry.Name(), Type: "version", Path: v})
 			}
-			if es, err := os.ReadDir(filepath.Join(root, entry.Name(), "cache")); err == nil {
-				for _, e := range es {
-					fmt.Println(filepath.Join(root, entry.Name(), "cache", e.Name()))
+			for _, typ := range []string{"cache", "versions"} {
+				if es, err := os.ReadDir(filepath.Join(root, entry.Name(), typ)); err == nil {
+					for _, e := range es {
+						if typ == "cache" && e.Name() == language.CachedListFile {
+							continue
+						}
+						results = append(results, &fileResult{Language: entry.Name(), Type: typ, Path: filepath.Join(root, entry.Name(), typ, e.Name())})
+					}
 				}
 			}
-			if es, err := os.ReadDir(filepath.Join(root, entry.Name(), "versions")); err == nil {
-				for _, e := range es {
-					fmt.Println(filepath.Join(root, entry.Name(), "versions", e.Name()))
-				}
+		}
+		if jsonOutput {
+			if err := printJSON(results); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
 			}
+			os.Exit(0)
+		}
+		for _, res := range results {
+			fmt.Println(res.Path)
 		}
 		os.Exit(0)
 	}
 
-	var lang *language.Language
-	if l := os.Args[1]; slices.Contains(language.All, l) {
-		lang = &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
-	} else {
-		fmt.Fprintln(os.Stderr, "unknown language: "+l)
+	lang := &language.Language{Name: os.Args[1], Root: filepath.Join(root, os.Args[1]), Config: cfg}
+	if _, err := lang.Specific(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 	if err := lang.Init(); err != nil {
@@ -260,11 +418,19 @@ func main() {
 	err2 := func(command string, args ...string) error {
 		switch command {
 		case "versions":
+			args, jsonOutput := jsonFlag(args)
 			vs, err := lang.Versions()
 			if err != nil {
 				return err
 			}
 			current, _ := lang.Version()
+			if jsonOutput {
+				results := []*versionResult{}
+				for _, v := range vs {
+					results = append(results, newVersionResult(lang, v, current))
+				}
+				return printJSON(results)
+			}
 			bare := len(args) > 0 && args[0] == "--bare"
 			for _, v := range vs {
 				if bare {
@@ -278,10 +444,14 @@ func main() {
 				fmt.Println(mark + v)
 			}
 		case "version":
+			_, jsonOutput := jsonFlag(args)
 			v, err := lang.Version()
 			if err != nil {
 				return err
 			}
+			if jsonOutput {
+				return printJSON(newVersionResult(lang, v, v))
+			}
 			fmt.Println(v)
 		case "global":
 			if len(args) == 0 {
@@ -298,12 +468,20 @@ func main() {
 			if err := lang.SetVersion(version); err != nil {
 				return err
 			}
-			return lang.Rehash()
+			if err := lang.Rehash(); err != nil {
+				return err
+			}
+			return lang.RunHooks("post-global", version)
 		case "latest":
+			_, jsonOutput := jsonFlag(args)
 			latest, err := lang.Latest(context.Background())
 			if err != nil {
 				return err
 			}
+			if jsonOutput {
+				current, _ := lang.Version()
+				return printJSON(newVersionResult(lang, latest, current))
+			}
 			fmt.Println(latest)
 		case "rehash":
 			return lang.Rehash()
@@ -311,11 +489,19 @@ func main() {
 			if len(args) == 0 {
 				return errors.New("need version argument")
 			}
-			if args[0] == "-l" || args[0] == "-L" {
-				versions, err := lang.List(context.Background(), args[0] == "-L")
+			if listArgs, jsonOutput := jsonFlag(args); len(listArgs) > 0 && (listArgs[0] == "-l" || listArgs[0] == "-L") {
+				versions, err := lang.List(context.Background(), listArgs[0] == "-L")
 				if err != nil {
 					return err
 				}
+				if jsonOutput {
+					current, _ := lang.Version()
+					results := []*versionResult{}
+					for _, version := range versions {
+						results = append(results, newVersionResult(lang, version, current))
+					}
+					return printJSON(results)
+				}
 				for _, version := range versions {
 					fmt.Println(version)
 				}
@@ -329,21 +515,28 @@ func main() {
 			if len(args) == 0 {
 				return errors.New("need version argument")
 			}
-			version := args[0]
-			version2, err := lang.Install(context.Background(), version)
-			if err != nil || !global {
-				return err
+			var items []*installItem
+			for _, version := range args {
+				items = append(items, &installItem{lang: lang, version: version})
 			}
-			if err := lang.SetVersion(version2); err != nil {
-				return err
-			}
-			return lang.Rehash()
+			return installMany(context.Background(), items, global)
 		case "reset":
 			if len(args) == 0 {
 				return errors.New("need version argument")
 			}
 			version := args[0]
 			return lang.Reset(version)
+		case "upgrade":
+			return upgrade(context.Background(), lang, args)
+		case "uninstall":
+			if len(args) == 0 {
+				return errors.New("need version argument")
+			}
+			version := args[0]
+			if err := lang.Uninstall(version); err != nil {
+				return err
+			}
+			return lang.RunHooks("post-uninstall", version)
 		default:
 			return errors.New("unknown command: " + command)
 		}
@@ -366,3 +559,23 @@ func selectRoot() (string, error) {
 	}
 	return filepath.Abs(root)
 }
+
+func readConfig(root string) (*config.Config, error) {
+	path := filepath.Join(root, "config.json")
+	if !language.ExistsFS(path) {
+		return nil, nil
+	}
+	cfg, err := config.NewFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Languages)) {
+		if err := language.CheckName(name); err != nil {
+			return nil, fmt.Errorf("%s: languages.%s: %w", path, name, err)
+		}
+		if _, ok := language.Lookup(name); ok {
+			return nil, fmt.Errorf("%s: languages.%s conflicts with the builtin language %s", path, name, name)
+		}
+	}
+	return cfg, nil
+}
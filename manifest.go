@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+// manifest is the versions of languages that a project uses.
+// It is either tinyenv.json
+//
+//	{
+//	  "versions": {"go": "1.23.1", "node": "latest"},
+//	  "lock": {"go": {"requested": "1.23.1", "version": "1.23.1", "url": "...", "sha256": "..."}}
+//	}
+//
+// or asdf's .tool-versions, whose lock is written to .tool-versions.lock
+type manifest struct {
+	path     string
+	Versions map[string]string     `json:"versions"`
+	Lock     map[string]*lockEntry `json:"lock,omitempty"`
+}
+
+type lockEntry struct {
+	Requested string `json:"requested"`
+	Version   string `json:"version"`
+	URL       string `json:"url,omitempty"`
+	SHA256    string `json:"sha256,omitempty"`
+}
+
+var manifestNames = []string{"tinyenv.json", ".tool-versions"}
+
+// loadManifest reads the manifest given in args, or finds one from the current directory
+func loadManifest(args []string) (*manifest, error) {
+	if len(args) > 0 {
+		return readManifest(args[0])
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	m, err := findManifest(cwd)
+	if err == nil && m == nil {
+		return nil, errors.New("no tinyenv.json or .tool-versions found")
+	}
+	return m, err
+}
+
+// findManifest finds a manifest in dir or its parents; it returns nil if there is none
+func findManifest(dir string) (*manifest, error) {
+	for {
+		for _, name := range manifestNames {
+			if path := filepath.Join(dir, name); language.ExistsFS(path) {
+				return readManifest(path)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func readManifest(path string) (*manifest, error) {
+	m := &manifest{path: path}
+	if filepath.Base(path) == ".tool-versions" {
+		versions, err := parseToolVersions(path)
+		if err != nil {
+			return nil, err
+		}
+		m.Versions = versions
+		if b, err := os.ReadFile(m.lockPath()); err == nil {
+			if err := json.Unmarshal(b, &m.Lock); err != nil {
+				return nil, fmt.Errorf("%s: %w", m.lockPath(), err)
+			}
+		}
+		return m, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+func (m *manifest) lockPath() string {
+	if filepath.Base(m.path) == ".tool-versions" {
+		return m.path + ".lock"
+	}
+	return m.path
+}
+
+// locked returns the lock entry of name if it is still for the requested version
+func (m *manifest) locked(name string) *lockEntry {
+	if entry, ok := m.Lock[name]; ok && entry.Requested == m.Versions[name] {
+		return entry
+	}
+	return nil
+}
+
+// resolved returns the locked version of name, or the requested one
+func (m *manifest) resolved(name string) string {
+	if entry := m.locked(name); entry != nil {
+		return entry.Version
+	}
+	return m.Versions[name]
+}
+
+func (m *manifest) writeLock() error {
+	var v any = m
+	if m.lockPath() != m.path {
+		v = m.Lock
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.lockPath(), append(b, '\n'), 0o644)
+}
+
+// asdf names some languages differently
+var toolVersionsAliases = map[string]string{
+	"golang": "go",
+	"nodejs": "node",
+}
+
+func parseToolVersions(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	out := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		name := fields[0]
+		if alias, ok := toolVersionsAliases[name]; ok {
+			name = alias
+		}
+		out[name] = fields[1]
+	}
+	return out, scanner.Err()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseToolVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{"plain", "go 1.23.1\npython 3.12.8\n", map[string]string{"go": "1.23.1", "python": "3.12.8"}},
+		{"aliases", "golang 1.23.1\nnodejs 22.12.0\n", map[string]string{"go": "1.23.1", "node": "22.12.0"}},
+		{"comments and blank lines", "# tools\n\ngo 1.23.1 # pinned\n  \n", map[string]string{"go": "1.23.1"}},
+		{"fallback versions", "node 22.12.0 20.18.1\n", map[string]string{"node": "22.12.0"}},
+		{"no version", "go\nruby 3.3.6\n", map[string]string{"ruby": "3.3.6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".tool-versions")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseToolVersions(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseToolVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestLocked(t *testing.T) {
+	m := &manifest{
+		Versions: map[string]string{"go": "1.23", "node": "22", "python": "3.12"},
+		Lock: map[string]*lockEntry{
+			"go":   {Requested: "1.23", Version: "1.23.4"},
+			"node": {Requested: "20", Version: "v20.18.1"},
+		},
+	}
+	tests := []struct {
+		name     string
+		locked   bool
+		resolved string
+	}{
+		{"go", true, "1.23.4"},
+		// the requested version has changed since the lock was written
+		{"node", false, "22"},
+		{"python", false, "3.12"},
+		{"ruby", false, ""},
+	}
+	for _, tt := range tests {
+		if got := m.locked(tt.name); (got != nil) != tt.locked {
+			t.Errorf("locked(%s) = %v, want locked %v", tt.name, got, tt.locked)
+		}
+		if got := m.resolved(tt.name); got != tt.resolved {
+			t.Errorf("resolved(%s) = %s, want %s", tt.name, got, tt.resolved)
+		}
+	}
+}
+
+func TestManifestWriteLock(t *testing.T) {
+	lock := map[string]*lockEntry{"go": {Requested: "1.23", Version: "1.23.4", URL: "https://go.dev/dl/go1.23.4.linux-amd64.tar.gz", SHA256: "abc"}}
+	tests := []struct {
+		name     string
+		content  string
+		lockName string
+	}{
+		{"tinyenv.json", `{"versions": {"go": "1.23"}}`, "tinyenv.json"},
+		{".tool-versions", "golang 1.23\n", ".tool-versions.lock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			m, err := readManifest(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.Lock = lock
+			if err := m.writeLock(); err != nil {
+				t.Fatal(err)
+			}
+			if m.lockPath() != filepath.Join(dir, tt.lockName) {
+				t.Errorf("lockPath() = %s, want %s", m.lockPath(), tt.lockName)
+			}
+			if tt.lockName != tt.name {
+				if b, _ := os.ReadFile(path); string(b) != tt.content {
+					t.Errorf("%s is changed to %q", tt.name, b)
+				}
+			}
+
+			m2, err := readManifest(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m2.Versions["go"] != "1.23" {
+				t.Errorf("versions = %v", m2.Versions)
+			}
+			got, _ := json.Marshal(m2.Lock)
+			want, _ := json.Marshal(lock)
+			if string(got) != string(want) {
+				t.Errorf("lock = %s, want %s", got, want)
+			}
+		})
+	}
+}
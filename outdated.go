@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/skaji/tinyenv/config"
+	"github.com/skaji/tinyenv/language"
+)
+
+// outdatedResult is the JSON schema of outdated
+type outdatedResult struct {
+	Language string `json:"language"`
+	Version  string `json:"version"`
+	Series   string `json:"series,omitempty"`
+	Newest   string `json:"newest,omitempty"`
+	Current  bool   `json:"current"`
+	Outdated bool   `json:"outdated"`
+	Error    string `json:"error,omitempty"`
+}
+
+// outdated compares every installed version with the newest release in its minor (or major) series.
+// It returns true if some version is outdated or some language could not be checked.
+func outdated(ctx context.Context, root string, cfg *config.Config, languages []string, major bool, jsonOutput bool) (bool, error) {
+	results := make([][]*outdatedResult, len(languages))
+	var wg sync.WaitGroup
+	for i, l := range languages {
+		lang := &language.Language{Name: l, Root: filepath.Join(root, l), Config: cfg}
+		installed, err := lang.Versions()
+		if err != nil || len(installed) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			current, _ := lang.Version()
+			candidates, err := lang.List(ctx, true)
+			for _, version := range installed {
+				res := &outdatedResult{Language: l, Version: version, Current: version == current}
+				if err != nil {
+					res.Error = err.Error()
+				} else if series, ok := language.Series(version, major); ok {
+					res.Series = series
+					res.Newest = language.NewestInSeries(version, candidates, major)
+					res.Outdated = res.Newest != version
+				}
+				results[i] = append(results[i], res)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var all []*outdatedResult
+	drift := false
+	for _, rs := range results {
+		for _, res := range rs {
+			all = append(all, res)
+			if res.Outdated || res.Error != "" {
+				drift = true
+			}
+		}
+	}
+	if jsonOutput {
+		if all == nil {
+			all = []*outdatedResult{}
+		}
+		return drift, printJSON(all)
+	}
+	format := "%-8s  %-24s  %-12s  %s\n"
+	fmt.Printf(format, "language", "version", "series", "newest")
+	fmt.Printf(format, "--------", "-------", "------", "------")
+	for _, res := range all {
+		version := res.Version
+		if res.Current {
+			version = "* " + version
+		}
+		newest := res.Newest
+		switch {
+		case res.Error != "":
+			newest = "error: " + res.Error
+		case res.Series == "":
+			newest = "(not a stable version)"
+		case !res.Outdated:
+			newest += " (up to date)"
+		}
+		fmt.Printf(format, res.Language, version, res.Series, newest)
+	}
+	return drift, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+// versionResult is the JSON schema of versions, version, latest and install -l
+type versionResult struct {
+	Language  string `json:"language"`
+	Version   string `json:"version"`
+	Current   bool   `json:"current"`
+	Installed bool   `json:"installed"`
+	Path      string `json:"path,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newVersionResult(lang *language.Language, version string, current string) *versionResult {
+	res := &versionResult{
+		Language: lang.Name,
+		Version:  version,
+		Current:  version != "" && version == current,
+	}
+	if version == "" {
+		return res
+	}
+	if dir := filepath.Join(lang.Root, "versions", version); language.ExistsFS(dir) {
+		res.Installed = true
+		res.Path = dir
+	}
+	return res
+}
+
+// fileResult is the JSON schema of files
+type fileResult struct {
+	Language string `json:"language,omitempty"`
+	// bin, version, cache or versions
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// jsonFlag removes --json from args, and reports whether it was there
+func jsonFlag(args []string) ([]string, bool) {
+	if !slices.Contains(args, "--json") {
+		return args, false
+	}
+	return slices.DeleteFunc(slices.Clone(args), func(arg string) bool { return arg == "--json" }), true
+}
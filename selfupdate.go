@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/skaji/tinyenv/language"
+	"golang.org/x/mod/semver"
+)
+
+const tinyenvURL = "https://github.com/skaji/tinyenv"
+
+// selfUpdate replaces the running executable with the newest release of tinyenv.
+// If check is true, it only tells whether there is a newer release.
+func selfUpdate(ctx context.Context, check bool) error {
+	g := &language.GitHub{}
+	tags, err := g.Tags(ctx, tinyenvURL)
+	if err != nil {
+		return err
+	}
+	newest := ""
+	for _, tag := range tags {
+		if semver.IsValid(tag) && semver.Prerelease(tag) == "" && (newest == "" || semver.Compare(tag, newest) > 0) {
+			newest = tag
+		}
+	}
+	if newest == "" {
+		return errors.New("no release found in " + tinyenvURL)
+	}
+	current := "v" + strings.TrimPrefix(version, "v")
+	if semver.IsValid(current) && semver.Compare(current, newest) >= 0 {
+		fmt.Printf("tinyenv %s is the newest\n", version)
+		return nil
+	}
+	if check {
+		fmt.Printf("tinyenv %s is available (current %s)\n", newest, version)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	assets, err := g.Assets(ctx, tinyenvURL, newest)
+	if err != nil {
+		return err
+	}
+	archiveName := fmt.Sprintf("tinyenv-%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	var archiveURL, checksumsURL string
+	for _, asset := range assets {
+		switch {
+		case strings.HasSuffix(asset, "/"+archiveName):
+			archiveURL = asset
+		case strings.HasSuffix(asset, "checksums.txt"):
+			checksumsURL = asset
+		}
+	}
+	if archiveURL == "" || checksumsURL == "" {
+		return errors.New("cannot find " + archiveName + " and its checksums in " + newest)
+	}
+	checksums, err := language.HTTPGet(ctx, checksumsURL)
+	if err != nil {
+		return err
+	}
+	sha256 := ""
+	for _, line := range strings.Split(string(checksums), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[1] == archiveName {
+			sha256 = fields[0]
+		}
+	}
+	if sha256 == "" {
+		return errors.New("cannot find the checksum of " + archiveName + " in " + checksumsURL)
+	}
+
+	// work next to the executable, so that the final rename is atomic
+	tempDir, err := os.MkdirTemp(filepath.Dir(exe), ".tinyenv-update-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	archive := filepath.Join(tempDir, archiveName)
+	fmt.Println("---> Downloading " + archiveURL)
+	if err := language.HTTPMirror(ctx, archiveURL, archive, nil); err != nil {
+		return err
+	}
+	if err := language.VerifySHA256(archive, sha256); err != nil {
+		return err
+	}
+	extractDir := filepath.Join(tempDir, "tinyenv")
+	fmt.Println("---> Extracting " + archive)
+	if err := language.UntarStrip(archive, extractDir, 0); err != nil {
+		return err
+	}
+	newExe := filepath.Join(extractDir, "tinyenv")
+	if err := os.Chmod(newExe, 0o755); err != nil {
+		return err
+	}
+	fmt.Println("---> Replacing " + exe)
+	if err := os.Rename(newExe, exe); err != nil {
+		return err
+	}
+	fmt.Printf("tinyenv %s -> %s\n", version, newest)
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/skaji/tinyenv/config"
+	"github.com/skaji/tinyenv/language"
+)
+
+// syncManifest installs the versions in m that are missing in parallel, and writes the lock
+func syncManifest(ctx context.Context, root string, cfg *config.Config, languages []string, m *manifest) error {
+	names := slices.Sorted(maps.Keys(m.Versions))
+	for _, name := range names {
+		if !slices.Contains(languages, name) {
+			return fmt.Errorf("%s: unknown language: %s", m.path, name)
+		}
+	}
+	items := make([]*installItem, len(names))
+	for i, name := range names {
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		item := &installItem{lang: lang, version: m.Versions[name], recorder: &language.DownloadRecorder{}}
+		if locked := m.locked(name); locked != nil {
+			item.version = locked.Version
+			item.recorder.ExpectedSHA256 = locked.SHA256
+		}
+		items[i] = item
+	}
+	installErr := installMany(ctx, items, false)
+
+	lock := map[string]*lockEntry{}
+	for i, name := range names {
+		if items[i].err != nil {
+			if entry, ok := m.Lock[name]; ok {
+				lock[name] = entry
+			}
+			continue
+		}
+		entry, err := syncLockEntry(items[i], m.Versions[name], m.locked(name))
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			lock[name] = entry
+		}
+	}
+	m.Lock = lock
+	if err := m.writeLock(); err != nil {
+		return err
+	}
+	if installErr != nil {
+		return errors.New("failed to sync " + m.path)
+	}
+	return nil
+}
+
+// syncLockEntry returns the lock entry of a synced item, or nil if it cannot be locked
+func syncLockEntry(item *installItem, requested string, locked *lockEntry) (*lockEntry, error) {
+	if locked != nil {
+		return locked, nil
+	}
+	name, version := item.lang.Name, item.installed
+	if !item.skipped {
+		d := item.recorder.Last()
+		if d == nil {
+			fmt.Printf("---> %s %s did not download an archive, so it is not locked\n", name, version)
+			return nil, nil
+		}
+		return &lockEntry{Requested: requested, Version: version, URL: d.URL, SHA256: d.SHA256}, nil
+	}
+	cacheFile := item.lang.CacheFile(version)
+	if cacheFile == "" {
+		fmt.Printf("---> %s %s is installed, but its archive is not in cache, so it is not locked\n", name, version)
+		return nil, nil
+	}
+	sha256, err := language.SHA256File(cacheFile)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("---> %s %s is installed, so it is locked with the sha256 of %s\n", name, version, cacheFile)
+	return &lockEntry{Requested: requested, Version: version, SHA256: sha256}, nil
+}
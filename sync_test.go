@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+func TestSyncLockEntry(t *testing.T) {
+	root := t.TempDir()
+	lang := &language.Language{Name: "go", Root: filepath.Join(root, "go")}
+	if err := os.MkdirAll(filepath.Join(lang.Root, "cache"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cacheFile := filepath.Join(lang.Root, "cache", "1.23.4.tar.gz")
+	if err := os.WriteFile(cacheFile, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sha256, err := language.SHA256File(cacheFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked := &lockEntry{Requested: "1.23", Version: "1.23.4", URL: "https://example.com/go.tar.gz", SHA256: "abc"}
+
+	tests := []struct {
+		name   string
+		item   *installItem
+		locked *lockEntry
+		want   *lockEntry
+	}{
+		{
+			"locked",
+			&installItem{lang: lang, installed: "1.23.4", recorder: &language.DownloadRecorder{}},
+			locked,
+			locked,
+		},
+		{
+			"installed and cached",
+			&installItem{lang: lang, installed: "1.23.4", skipped: true, recorder: &language.DownloadRecorder{}},
+			nil,
+			&lockEntry{Requested: "1.23", Version: "1.23.4", SHA256: sha256},
+		},
+		{
+			"installed but not cached",
+			&installItem{lang: lang, installed: "1.22.10", skipped: true, recorder: &language.DownloadRecorder{}},
+			nil,
+			nil,
+		},
+		{
+			"installed without a download",
+			&installItem{lang: lang, installed: "1.23.4", recorder: &language.DownloadRecorder{}},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := syncLockEntry(tt.item, "1.23", tt.locked)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("syncLockEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/skaji/tinyenv/language"
+)
+
+// upgrade installs the newest version in the series of the global version (or the given version),
+// switches the global version if it was the global one, and optionally removes the old version.
+//
+//	tinyenv LANG upgrade [--major] [--remove] [VERSION]
+func upgrade(ctx context.Context, lang *language.Language, args []string) error {
+	major := slices.Contains(args, "--major")
+	remove := slices.Contains(args, "--remove")
+	args = slices.DeleteFunc(args, func(arg string) bool { return arg == "--major" || arg == "--remove" })
+
+	global, _ := lang.Version()
+	old := global
+	if len(args) > 0 {
+		old = args[0]
+	}
+	if old == "" {
+		return errors.New("no version set; specify a version to upgrade")
+	}
+	installed, err := lang.Versions()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(installed, old) {
+		return errors.New("invalid version: " + old)
+	}
+	if _, ok := language.Series(old, major); !ok {
+		return errors.New("cannot upgrade " + old + ", which is not a stable version")
+	}
+
+	candidates, err := lang.List(ctx, true)
+	if err != nil {
+		return err
+	}
+	newest := language.NewestInSeries(old, candidates, major)
+	if newest == old {
+		fmt.Printf("%s is already the newest in its series\n", old)
+		return nil
+	}
+	if slices.Contains(installed, newest) {
+		fmt.Printf("---> %s is already installed\n", newest)
+	} else {
+		if _, err := lang.Install(ctx, newest); err != nil {
+			return err
+		}
+		if err := lang.RunHooks("post-install", newest); err != nil {
+			return err
+		}
+	}
+	if old == global {
+		fmt.Printf("---> Switching global version from %s to %s\n", old, newest)
+		if err := lang.SetVersion(newest); err != nil {
+			return err
+		}
+		if err := lang.Rehash(); err != nil {
+			return err
+		}
+		if err := lang.RunHooks("post-global", newest); err != nil {
+			return err
+		}
+	}
+	if remove {
+		if err := lang.Uninstall(old); err != nil {
+			return err
+		}
+		return lang.RunHooks("post-uninstall", old)
+	}
+	return nil
+}
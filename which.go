@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skaji/tinyenv/config"
+	"github.com/skaji/tinyenv/language"
+)
+
+// which prints the executable that the shim of command execs
+func which(root string, command string) error {
+	shim, err := language.ReadShim(filepath.Join(root, "bin", command))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("no shim for " + command)
+		}
+		return err
+	}
+	if !language.ExistsFS(shim.Target) {
+		return fmt.Errorf("%s (%s) does not exist; run `tinyenv %s rehash`", shim.Target, shim.Language, shim.Language)
+	}
+	fmt.Printf("%s (%s)\n", shim.Target, shim.Language)
+	return nil
+}
+
+// whence prints every installed version of every language that provides command
+func whence(root string, cfg *config.Config, languages []string, command string) error {
+	found := false
+	for _, name := range languages {
+		lang := &language.Language{Name: name, Root: filepath.Join(root, name), Config: cfg}
+		specific, err := lang.Specific()
+		if err != nil {
+			continue
+		}
+		versions, err := lang.Versions()
+		if err != nil {
+			continue
+		}
+		current, _ := lang.Version()
+		for _, version := range versions {
+			for _, binDir := range specific.BinDirs() {
+				path := filepath.Join(lang.Root, "versions", version, binDir, command)
+				info, err := os.Stat(path)
+				if err != nil || info.IsDir() || info.Mode()&0o111 == 0 {
+					continue
+				}
+				mark := "  "
+				if version == current {
+					mark = "* "
+				}
+				fmt.Printf("%s%s %s %s\n", mark, name, version, path)
+				found = true
+			}
+		}
+	}
+	if !found {
+		return errors.New("no installed version provides " + command)
+	}
+	return nil
+}